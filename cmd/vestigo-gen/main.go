@@ -0,0 +1,63 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+// Command vestigo-gen generates a vestigo.Matcher for a route table, and a test
+// checking the matcher matches paths exactly as the router tree does.  It is
+// meant to be run by go generate:
+//
+//	//go:generate vestigo-gen -routes routes.txt -o matcher_gen.go
+//
+// The routes file declares one route per line, as "METHOD /path".  The package
+// of the generated source defaults to $GOPACKAGE, which go generate sets.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/husobee/vestigo/gen"
+)
+
+func main() {
+	var (
+		routesFile = flag.String("routes", "routes.txt", "file declaring the routes, one \"METHOD /path\" per line")
+		out        = flag.String("o", "matcher_gen.go", "file to write the matcher to, the test is written next to it")
+		pkg        = flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated source")
+		typ        = flag.String("type", "Matcher", "name of the generated matcher type")
+		test       = flag.Bool("test", true, "generate the equivalence test")
+	)
+	flag.Parse()
+
+	if err := run(*routesFile, *out, *pkg, *typ, *test); err != nil {
+		fmt.Fprintln(os.Stderr, "vestigo-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(routesFile, out, pkg, typ string, test bool) error {
+	f, err := os.Open(routesFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	routes, err := gen.ParseRoutes(f)
+	if err != nil {
+		return err
+	}
+
+	matcher, matcherTest, err := gen.Generate(routes, gen.Config{Package: pkg, Type: typ})
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(out, matcher, 0644); err != nil {
+		return err
+	}
+	if !test {
+		return nil
+	}
+	return ioutil.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", matcherTest, 0644)
+}
@@ -23,12 +23,28 @@ var methods = map[string]bool{
 	http.MethodTrace:   true,
 }
 
+// contextKey - type of the keys vestigo stores request context values under
+type contextKey int
+
+const (
+	matchKey contextKey = iota
+	routeKey
+	mountKey
+	methodKey
+	versionKey
+)
+
 // AllowTrace - Globally allow the TRACE method handling within vestigo url router.  This
 // generally not a good idea to have true in production settings, but excellent for testing.
 var AllowTrace = false
 
 // Param - Get a url parameter by name
 func Param(r *http.Request, name string) string {
+	if rc := routeContextFrom(r); rc != nil {
+		if value, ok := rc.param(name); ok {
+			return value
+		}
+	}
 	return r.URL.Query().Get(":" + name)
 }
 
@@ -54,6 +70,76 @@ func TrimmedParamNames(r *http.Request) []string {
 	return names
 }
 
+// addParams - Add the url params of a route to the request query, building the
+// new query string with a single allocation
+func addParams(r *http.Request, pnames, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	n := len(r.URL.RawQuery)
+	for i, v := range values {
+		if i < len(pnames) {
+			n += len("&%3A=") + queryEscapedLen(pnames[i]) + queryEscapedLen(v)
+		}
+	}
+	var b strings.Builder
+	b.Grow(n)
+	b.WriteString(r.URL.RawQuery)
+	for i, v := range values {
+		if i >= len(pnames) {
+			break
+		}
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString("%3A")
+		writeQueryEscaped(&b, pnames[i])
+		b.WriteByte('=')
+		writeQueryEscaped(&b, v)
+	}
+	r.URL.RawQuery = b.String()
+}
+
+// queryEscapedLen - the length of the string once escaped by writeQueryEscaped
+func queryEscapedLen(s string) int {
+	n := len(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' && shouldQueryEscape(s[i]) {
+			n += 2
+		}
+	}
+	return n
+}
+
+// writeQueryEscaped - write the string escaped the way url.QueryEscape does,
+// without allocating an escaped copy first
+func writeQueryEscaped(b *strings.Builder, s string) {
+	const hex = "0123456789ABCDEF"
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == ' ':
+			b.WriteByte('+')
+		case shouldQueryEscape(c):
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// shouldQueryEscape - whether a byte is escaped in a query component
+func shouldQueryEscape(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return false
+	case c == '-', c == '_', c == '.', c == '~':
+		return false
+	}
+	return true
+}
+
 // AddParam - Add a vestigo-style parameter to the request -- useful for middleware
 // Appends :name=value onto a blank request query string or appends &:name=value
 // onto a non-blank request query string
@@ -66,6 +152,12 @@ func AddParam(r *http.Request, name, value string) {
 	}
 }
 
+// isCorsMethod - is the method one of the pseudo methods used to register CORS
+// policies on a resource, "CORS" or "CORS <method>"
+func isCorsMethod(method string) bool {
+	return strings.HasPrefix(method, "CORS")
+}
+
 //validMethod - validate that the http method is valid.
 func validMethod(method string) bool {
 	_, ok := methods[method]
@@ -59,3 +59,20 @@ func TestTrimmedParamNames(t *testing.T) {
 	router.ServeHTTP(rec, req3)
 	router.ServeHTTP(rec, req4)
 }
+
+func TestAddParamsMatchesAddParam(t *testing.T) {
+	pnames := []string{"id", "na me", "path"}
+	values := []string{"1", "a b&c=d", "x/y:z~_.-"}
+
+	expected, _ := http.NewRequest("GET", "/?a=b", nil)
+	for i := range pnames {
+		AddParam(expected, pnames[i], values[i])
+	}
+	actual, _ := http.NewRequest("GET", "/?a=b", nil)
+	addParams(actual, pnames, values)
+	assert.Equal(t, expected.URL.RawQuery, actual.URL.RawQuery)
+
+	empty, _ := http.NewRequest("GET", "/", nil)
+	addParams(empty, pnames[:1], values[:1])
+	assert.Equal(t, "%3Aid=1", empty.URL.RawQuery)
+}
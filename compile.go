@@ -0,0 +1,234 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// corsKey - key of the CORS policies worked out by Compile
+type corsKey struct {
+	res      *resource
+	method   string
+	template string
+}
+
+// Compile - Validate the route table and turn it into a read only matcher.  The
+// tree is copied into contiguous arrays, routes without url params are matched
+// with a single map lookup, and the handler of every route and method is built
+// with the CORS headers of its merged policy worked out for every origin the
+// policy lists, which is done again on ReloadCors.  Preflights are still
+// evaluated against the method and headers they ask for.  Once
+// compiled, adding routes, CORS policies, names, metadata or middleware to the
+// router panics.  Compile is meant to be called once startup is complete, before
+// the router serves any requests, and does nothing when the router is already
+// compiled.
+func (r *Router) Compile() error {
+	if r.compiled {
+		return nil
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	r.root = flatten(r.root)
+	if r.matcher != nil {
+		r.matcherNodes = r.root.breadthFirst()
+	}
+
+	statics := make(map[string]*node)
+	var storage [maxParams]string
+	for template := range r.templates {
+		if strings.ContainsAny(template, ":*") {
+			continue
+		}
+		// only take the route the tree would find for the path, so the
+		// map never disagrees with the tree
+		if n, values := r.lookup(template, storage[:0]); n != nil && len(values) == 0 && n == r.root.findNode(template) {
+			statics[template] = n
+		}
+	}
+	r.statics = statics
+	r.corsMu.Lock()
+	defer r.corsMu.Unlock()
+	r.compiled = true
+	r.cors.Store(r.compileState(r.corsState().config))
+	return nil
+}
+
+// validate - check that every route can be found, and that the names, metadata
+// and CORS policies refer to routes that exist and are valid
+func (r *Router) validate() error {
+	templates := make([]string, 0, len(r.templates))
+	for template := range r.templates {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+	for _, template := range templates {
+		if n := r.root.findNode(strippedPath(template)); n == nil || n.resource.methods == 0 {
+			return fmt.Errorf("vestigo: %s: route can not be found", template)
+		}
+	}
+	for path := range r.names {
+		if !r.templates[path] {
+			return fmt.Errorf("vestigo: %s: name given to a route that does not exist", path)
+		}
+	}
+	for path := range r.metadata {
+		if !r.templates[path] {
+			return fmt.Errorf("vestigo: %s: metadata given to a route that does not exist", path)
+		}
+	}
+	if err := r.globalCors.validate(); err != nil {
+		return fmt.Errorf("vestigo: global cors: %v", err)
+	}
+	if c := r.corsState().config; c != nil {
+		if err := c.Validate(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compiledRoute - a route and method worked out by Compile, its handler with
+// the CORS policy of the route applied, and the template that describes it
+type compiledRoute struct {
+	handler  http.HandlerFunc
+	template string
+}
+
+// compileRoutes - work out the route of every node and method of the compiled
+// tree, indexed by the id of the node and the index of the method, with the
+// CORS policies worked out by compileCors
+func (r *Router) compileRoutes(policies map[corsKey]*CorsAccessControl) []compiledRoute {
+	var nodes []*node
+	r.root.walk(func(n *node) {
+		nodes = append(nodes, n)
+	})
+	routes := make([]compiledRoute, len(nodes)*len(setMethods))
+	for _, n := range nodes {
+		if n.resource == nil {
+			continue
+		}
+		for i, method := range setMethods {
+			h, methods := n.resource.GetMethodHandler(method)
+			if h == nil || !methods.has(method) {
+				continue
+			}
+			template := n.templateFor(method)
+			routes[n.id*len(setMethods)+i] = compiledRoute{
+				handler:  newFlightCors(policies[corsKey{n.resource, method, template}]).wrap(h),
+				template: template,
+			}
+		}
+	}
+	return routes
+}
+
+// compiledRoute - the route worked out by Compile for a method of a node, nil
+// when the router is not compiled
+func (s *corsState) compiledRoute(n *node, method string) *compiledRoute {
+	i := methodIndex(method)
+	if s.routes == nil || i < 0 {
+		return nil
+	}
+	if cr := &s.routes[n.id*len(setMethods)+i]; cr.handler != nil {
+		return cr
+	}
+	return nil
+}
+
+// compileCors - work out the CORS policy of every route and method, with the
+// CORS configuration c
+func (r *Router) compileCors(c *CorsConfig) map[corsKey]*CorsAccessControl {
+	policies := make(map[corsKey]*CorsAccessControl)
+	r.root.walk(func(n *node) {
+		if n.resource == nil || n.resource.methods == 0 {
+			return
+		}
+		for method := range methods {
+			template := n.templateFor(method)
+			policies[corsKey{n.resource, method, template}] = r.mergedCorsPolicy(c, n.resource, template, method)
+		}
+	})
+	return policies
+}
+
+// mustNotBeCompiled - panic when the router has been compiled
+func (r *Router) mustNotBeCompiled() {
+	if r.compiled {
+		panic("vestigo: router is compiled, it can not be changed")
+	}
+}
+
+// strippedPath - the path template with its param names stripped, as it is
+// inserted into the tree
+func strippedPath(template string) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		b.WriteByte(template[i])
+		switch template[i] {
+		case ':':
+			for i+1 < len(template) && template[i+1] != '/' {
+				i++
+			}
+		case '*':
+			return b.String()
+		}
+	}
+	return b.String()
+}
+
+// walk - call f on this node and every node below it
+func (n *node) walk(f func(*node)) {
+	f(n)
+	for _, c := range n.children {
+		c.walk(f)
+	}
+}
+
+// flatten - copy the tree into contiguous arrays, nodes next to their
+// siblings, returning the new root
+func flatten(root *node) *node {
+	order := root.breadthFirst()
+
+	slab := make([]node, len(order))
+	index := make(map[*node]*node, len(order))
+	for i, n := range order {
+		index[n] = &slab[i]
+	}
+	rewire := func(n *node) *node {
+		if n == nil {
+			return nil
+		}
+		return index[n]
+	}
+
+	edges := make([]*node, 0, 2*len(order))
+	for i, n := range order {
+		f := &slab[i]
+		*f = *n
+		f.id = i
+		f.parent = rewire(n.parent)
+		f.paramChild = rewire(n.paramChild)
+		f.anyChild = rewire(n.anyChild)
+
+		start := len(edges)
+		for _, c := range n.children {
+			edges = append(edges, rewire(c))
+		}
+		f.children = edges[start:len(edges):len(edges)]
+		start = len(edges)
+		for _, c := range n.indexed {
+			edges = append(edges, rewire(c))
+		}
+		f.indexed = edges[start:len(edges):len(edges)]
+		f.indices = append([]byte(nil), n.indices...)
+	}
+	return &slab[0]
+}
@@ -0,0 +1,213 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_CompileMatchesTree(t *testing.T) {
+	tree, compiled := githubRouter(), githubRouter()
+	assert.NoError(t, compiled.Compile())
+	assert.NoError(t, compiled.Compile(), "compiling twice does nothing")
+
+	paths := []string{"/", "/nope", "/user/repos/", "/repos/a/b/issues", "/gists/1/star/x"}
+	for _, route := range api {
+		paths = append(paths, route.path, strings.Replace(route.path, ":", "x", -1))
+	}
+	for _, path := range paths {
+		for _, method := range []string{"GET", "POST", "DELETE"} {
+			h1, params1, template1, allowed1 := tree.Lookup(method, path)
+			h2, params2, template2, allowed2 := compiled.Lookup(method, path)
+			assert.Equal(t, h1 == nil, h2 == nil, method+" "+path)
+			assert.Equal(t, params1, params2, method+" "+path)
+			assert.Equal(t, template1, template2, method+" "+path)
+			assert.Equal(t, allowed1, allowed2, method+" "+path)
+
+			w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+			req1, _ := http.NewRequest(method, path, nil)
+			req2, _ := http.NewRequest(method, path, nil)
+			tree.ServeHTTP(w1, req1)
+			compiled.ServeHTTP(w2, req2)
+			assert.Equal(t, w1.Code, w2.Code, method+" "+path)
+			assert.Equal(t, w1.Header(), w2.Header(), method+" "+path)
+		}
+	}
+}
+
+func TestRouter_CompilePanicsOnChanges(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	assert.NoError(t, r.Compile())
+
+	for name, change := range map[string]func(){
+		"Get":           func() { r.Get("/other", func(w http.ResponseWriter, r *http.Request) {}) },
+		"SetCors":       func() { r.SetCors("/users/:id", &CorsAccessControl{}) },
+		"SetGlobalCors": func() { r.SetGlobalCors(&CorsAccessControl{}) },
+		"SetName":       func() { r.SetName("/users/:id", "user") },
+		"SetMetadata":   func() { r.SetMetadata("/users/:id", "key", "value") },
+		"Use":           func() { r.Use(func(f http.HandlerFunc) http.HandlerFunc { return f }) },
+		"Pre":           func() { r.Pre(func(f http.HandlerFunc) http.HandlerFunc { return f }) },
+		"Fallback":      func() { r.Fallback(http.NotFoundHandler()) },
+		"Route":         func() { r.Route("GET", "/routes").Handle(func(w http.ResponseWriter, req *http.Request) {}) },
+		"Versioning":    func() { r.Versioning(&VersionOptions{Header: "API-Version"}) },
+	} {
+		assert.Panics(t, change, name)
+	}
+}
+
+func TestRouter_CompileValidates(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	r.SetName("/user/:id", "user")
+	if err := r.Compile(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "/user/:id")
+	}
+
+	r = NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	r.SetMetadata("/users/:uid", "key", "value")
+	assert.Error(t, r.Compile())
+
+	r = NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	r.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com, admin.test.com"}})
+	assert.Error(t, r.Compile())
+}
+
+func TestRouter_CompiledCors(t *testing.T) {
+	r := NewRouter()
+	r.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com"}})
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	assert.NoError(t, r.Compile())
+
+	serve := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/users/1", nil)
+		req.Header.Set("Origin", "admin.test.com")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, "", serve().Header().Get("Access-Control-Allow-Origin"))
+
+	// reloading the CORS configuration works the policies out again
+	err := r.ReloadCors(strings.NewReader(`{"paths": {"/users/:id": {"policy": {"allowOrigin": ["admin.test.com"]}}}}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "admin.test.com", serve().Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestRouter_CompiledCorsMatchesTree(t *testing.T) {
+	policies := []*CorsAccessControl{
+		{AllowOrigin: []string{"test.com", "admin.test.com"}, AllowCredentials: true, ExposeHeaders: []string{"X-Total"}},
+		{AllowOrigin: []string{"*", "test.com"}, AllowCredentials: true},
+		{AllowOrigin: []string{"*"}, ExposeHeaders: []string{"X-Total", "X-Page"}},
+		{AllowOrigin: []string{"test.com"}, Disabled: true},
+	}
+	for i, global := range policies {
+		router := func() *Router {
+			r := NewRouter()
+			r.SetGlobalCors(global)
+			r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Vary", "Accept")
+			})
+			r.Post("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+			r.SetCors("/users/:id", &CorsAccessControl{AllowOrigin: []string{"users.com"}})
+			return r
+		}
+		tree, compiled := router(), router()
+		assert.NoError(t, compiled.Compile())
+
+		for _, origin := range []string{"", "test.com", "admin.test.com", "users.com", "other.com", "*"} {
+			for _, method := range []string{"GET", "POST"} {
+				w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+				req1, _ := http.NewRequest(method, "/users/1", nil)
+				req2, _ := http.NewRequest(method, "/users/1", nil)
+				if origin != "" {
+					req1.Header.Set("Origin", origin)
+					req2.Header.Set("Origin", origin)
+				}
+				tree.ServeHTTP(w1, req1)
+				compiled.ServeHTTP(w2, req2)
+				assert.Equal(t, w1.Header(), w2.Header(), "policy %d %s %q", i, method, origin)
+			}
+		}
+	}
+}
+
+func BenchmarkRouter_CorsGithubAll(b *testing.B) {
+	benchCorsGithubAll(b, githubRouter())
+}
+
+func BenchmarkRouter_CompiledCorsGithubAll(b *testing.B) {
+	r := githubRouter()
+	r.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com"}, ExposeHeaders: []string{"X-Total"}})
+	if err := r.Compile(); err != nil {
+		b.Fatal(err)
+	}
+	benchCorsGithubAll(b, r)
+}
+
+// benchCorsGithubAll - serve every route of the github api from an allowed
+// origin, with a global CORS policy
+func benchCorsGithubAll(b *testing.B, r *Router) {
+	if r.globalCors == nil {
+		r.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com"}, ExposeHeaders: []string{"X-Total"}})
+	}
+	requests := make([]*http.Request, len(api))
+	for i, route := range api {
+		requests[i], _ = http.NewRequest(route.method, route.path, nil)
+		requests[i].Header.Set("Origin", "test.com")
+	}
+	w := &benchResponseWriter{header: http.Header{}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, req := range requests {
+			req.URL.RawQuery = ""
+			r.ServeHTTP(w, req)
+		}
+	}
+}
+
+func BenchmarkRouter_CompiledGithubAll(b *testing.B) {
+	r := githubRouter()
+	if err := r.Compile(); err != nil {
+		b.Fatal(err)
+	}
+	requests := make([]*http.Request, len(api))
+	for i, route := range api {
+		requests[i], _ = http.NewRequest(route.method, route.path, nil)
+	}
+	w := &benchResponseWriter{header: http.Header{}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, req := range requests {
+			req.URL.RawQuery = ""
+			r.ServeHTTP(w, req)
+		}
+	}
+}
+
+func BenchmarkRouter_CompiledWideLookup(b *testing.B) {
+	r, paths := wideRouter()
+	if err := r.Compile(); err != nil {
+		b.Fatal(err)
+	}
+	var storage [maxParams]string
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			r.lookup(path, storage[:0])
+		}
+	}
+}
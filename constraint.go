@@ -0,0 +1,222 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// parseVarPattern - translate the variables of a gorilla/mux style pattern,
+// such as /articles/{category}/{id:[0-9]+}, into url params, returning the
+// path with :name params and the regular expressions the params are
+// constrained to, which have to match the whole param.  Variables have to end
+// their path segment, as url params do.
+func parseVarPattern(pattern string) (string, map[string]*regexp.Regexp, error) {
+	var (
+		path        strings.Builder
+		constraints map[string]*regexp.Regexp
+		names       = make(map[string]bool)
+	)
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '}':
+			return "", nil, fmt.Errorf("vestigo: pattern %s: unbalanced braces", pattern)
+		case '{':
+		default:
+			path.WriteByte(pattern[i])
+			continue
+		}
+
+		// the variable ends with the brace balancing its own, as
+		// regular expressions can have braces
+		depth, end := 1, i+1
+		for ; end < len(pattern) && depth > 0; end++ {
+			switch pattern[end] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		if depth > 0 {
+			return "", nil, fmt.Errorf("vestigo: pattern %s: unbalanced braces", pattern)
+		}
+		if end < len(pattern) && pattern[end] != '/' {
+			return "", nil, fmt.Errorf("vestigo: pattern %s: variables must end their path segment", pattern)
+		}
+
+		name, expr := pattern[i+1:end-1], ""
+		if j := strings.IndexByte(name, ':'); j >= 0 {
+			name, expr = name[:j], name[j+1:]
+		}
+		if !paramNameRegexp.MatchString(name) || names[name] {
+			return "", nil, fmt.Errorf("vestigo: pattern %s: invalid or duplicate variable name %q", pattern, name)
+		}
+		names[name] = true
+		if expr != "" {
+			re, err := regexp.Compile("^(?:" + expr + ")$")
+			if err != nil {
+				return "", nil, fmt.Errorf("vestigo: pattern %s: variable %s: %v", pattern, name, err)
+			}
+			if constraints == nil {
+				constraints = make(map[string]*regexp.Regexp)
+			}
+			constraints[name] = re
+		}
+		path.WriteString(":" + name)
+		i = end - 1
+	}
+	return path.String(), constraints, nil
+}
+
+// setConstraints - record the constraints of the url params of the route the
+// method was added with
+func (n *node) setConstraints(method string, constraints map[string]*regexp.Regexp) {
+	var ordered []*regexp.Regexp
+	if len(constraints) > 0 {
+		pnames := n.pnamesFor(method)
+		ordered = make([]*regexp.Regexp, len(pnames))
+		for i, pname := range pnames {
+			ordered[i] = constraints[pname]
+		}
+	}
+	if n.constraints == nil && ordered == nil {
+		return
+	}
+	if n.constraints == nil {
+		n.constraints = make(map[string][]*regexp.Regexp)
+	}
+	n.constraints[method] = ordered
+	if method == http.MethodGet {
+		n.constraints[http.MethodHead] = ordered
+	}
+}
+
+// conflicts - whether the node already has a route for the method that was
+// added with other param names or other constraints than the route being added,
+// as only one route per method is kept and its param names and constraints
+// would be applied to the other
+func (n *node) conflicts(method, template string, constraints map[string]*regexp.Regexp) bool {
+	existing, added := n.templates[method]
+	if !added {
+		return false
+	}
+	if existing != template {
+		return true
+	}
+	ordered := n.constraints[method]
+	if len(ordered) == 0 {
+		return len(constraints) > 0
+	}
+	for i, pname := range n.pnamesFor(method) {
+		re := constraints[pname]
+		if (re == nil) != (ordered[i] == nil) || re != nil && re.String() != ordered[i].String() {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfies - whether the url param values meet the constraints of the route
+// of the method, or when the node has no route for the method, of the route
+// that describes the node
+func (n *node) satisfies(method string, values []string) bool {
+	if n.constraints == nil {
+		return true
+	}
+	m := method
+	if _, added := n.pnames[m]; !added {
+		for _, d := range describeMethods {
+			if _, added := n.pnames[d]; added {
+				m = d
+				break
+			}
+		}
+	}
+	for i, re := range n.constraints[m] {
+		if re != nil && i < len(values) && !re.MatchString(values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedBy - the methods of the node whose routes the url param values meet
+// the constraints of
+func (n *node) allowedBy(values []string) methodSet {
+	if n.constraints == nil {
+		return n.resource.methods
+	}
+	var allowed methodSet
+	for _, method := range n.resource.methods.list() {
+		if n.satisfies(method, values) {
+			allowed |= methodBit(method)
+		}
+	}
+	return allowed
+}
+
+// match - look the path up in the tree, returning the node found, the url param
+// values captured, appended to values, and the methods of the node whose routes
+// the values meet the constraints of.  When they meet the constraints of none,
+// the tree is searched again, backtracking to param and match-any siblings, for
+// a node with routes they do meet the constraints of.
+func (r *Router) match(path string, values []string) (*node, []string, methodSet) {
+	cn, found := r.lookup(path, values)
+	if cn == nil {
+		return nil, nil, 0
+	}
+	if allowed := cn.allowedBy(found); allowed != 0 || cn.constraints == nil {
+		return cn, found, allowed
+	}
+	if cn, found = r.root.search(path, values[:0]); cn == nil {
+		return nil, nil, 0
+	}
+	return cn, found, cn.allowedBy(found)
+}
+
+// search - match the node against the start of the path, and search its
+// children, static ones first, then param ones, then match-any ones, for a node
+// with routes the url param values meet the constraints of
+func (n *node) search(path string, values []string) (*node, []string) {
+	switch n.typ {
+	case ptype:
+		i := strings.IndexByte(path, '/')
+		if i < 0 {
+			i = len(path)
+		}
+		values, path = append(values, path[:i]), path[i:]
+	case mtype:
+		values, path = append(values, path), ""
+	default:
+		if !strings.HasPrefix(path, n.prefix) {
+			return nil, nil
+		}
+		path = path[len(n.prefix):]
+	}
+
+	if path == "" && n.resource != nil && n.resource.methods != 0 && n.allowedBy(values) != 0 {
+		return n, values
+	}
+	for _, c := range n.children {
+		if c.typ == stype && path != "" {
+			if found, v := c.search(path, values); found != nil {
+				return found, v
+			}
+		}
+	}
+	if n.paramChild != nil && path != "" {
+		if found, v := n.paramChild.search(path, values); found != nil {
+			return found, v
+		}
+	}
+	if n.anyChild != nil {
+		return n.anyChild.search(path, values)
+	}
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVarPattern(t *testing.T) {
+	path, constraints, err := parseVarPattern("/articles/{category}/{id:[0-9]{1,4}}")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "/articles/:category/:id", path)
+		assert.Nil(t, constraints["category"])
+		assert.True(t, constraints["id"].MatchString("2016"))
+		assert.False(t, constraints["id"].MatchString("20160"), "the whole param has to match")
+	}
+
+	for _, pattern := range []string{
+		"/articles/{id",
+		"/articles/id}",
+		"/articles/{id}.json",
+		"/articles/{id:[0-9}",
+		"/articles/{}",
+		"/articles/{id}/{id}",
+		"/articles/{id:(}",
+	} {
+		_, _, err := parseVarPattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestRouter_VarConstraints(t *testing.T) {
+	echo := func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(Param(req, "category") + " " + Param(req, "id")))
+	}
+	r := NewRouter()
+	r.Get("/articles/{category}/{id:[0-9]+}", echo)
+	r.Delete("/articles/{category}/{id}", echo)
+	r.Put("/v{version:[12]}/articles/{category:[a-z]+}", echo)
+	r.HandleFunc("PATCH /articles/{category:[a-z]+}/{id:[0-9]+}", echo)
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/articles/go/42", 200, "go 42"},
+		{"HEAD", "/articles/go/42", 200, ""},
+		// the path matches the DELETE route, whose id is not constrained
+		{"GET", "/articles/go/x42", 405, ""},
+		{"HEAD", "/articles/go/x42", 405, ""},
+		{"DELETE", "/articles/go/x42", 200, "go x42"},
+		{"POST", "/articles/go/42", 405, ""},
+		{"POST", "/articles/go/x42", 405, ""},
+		{"PUT", "/v2/articles/go", 200, "go "},
+		{"PUT", "/v3/articles/go", 404, ""},
+		{"PUT", "/v1/articles/Go", 404, ""},
+		{"PATCH", "/articles/go/1", 200, "go 1"},
+		{"PATCH", "/articles/go/x", 405, ""},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		if c.body != "" {
+			assert.Equal(t, c.body, w.Body.String(), c.method+" "+c.path)
+		}
+	}
+
+	h, params, template, _ := r.Lookup("GET", "/articles/go/42")
+	assert.NotNil(t, h)
+	assert.Equal(t, map[string]string{"category": "go", "id": "42"}, params)
+	assert.Equal(t, "/articles/:category/:id", template)
+	h, _, _, allowed := r.Lookup("GET", "/articles/go/x")
+	assert.Nil(t, h)
+	assert.Equal(t, []string{"DELETE"}, allowed)
+	if m := r.Match(mustNewRequest("GET", "/articles/go/x")); assert.NotNil(t, m) {
+		assert.False(t, m.MethodAllowed)
+		assert.Equal(t, []string{"DELETE"}, m.AllowedMethods)
+	}
+	h, _, _, _ = r.Lookup("PUT", "/v3/articles/go")
+	assert.Nil(t, h)
+	assert.Nil(t, r.Match(mustNewRequest("PUT", "/v3/articles/go")))
+}
+
+func TestRouter_VarConstraintsBacktrack(t *testing.T) {
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(body + " " + Param(req, "id") + Param(req, "_name") + Param(req, "name")))
+		}
+	}
+	r := NewRouter()
+	r.Get("/files/{id:[0-9]+}", respond("id"))
+	r.Get("/files/*", respond("any"))
+	r.Get("/a/{id:[0-9]+}", respond("id"))
+	r.Post("/a/:name", respond("name"))
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		body, allow  string
+	}{
+		{"GET", "/files/42", 200, "id 42", ""},
+		// the constraint is not met, the wildcard sibling matches
+		{"GET", "/files/abc", 200, "any abc", ""},
+		{"GET", "/a/1", 200, "id 1", ""},
+		{"POST", "/a/xyz", 200, "name xyz", ""},
+		// only the POST route matches, as the GET route constraint is not met
+		{"DELETE", "/a/xyz", 405, "", "POST"},
+		{"GET", "/a/xyz", 405, "", "POST"},
+		{"DELETE", "/a/1", 405, "", "GET, HEAD, POST"},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		if c.code == 200 {
+			assert.Equal(t, c.body, w.Body.String(), c.method+" "+c.path)
+		} else {
+			assert.Equal(t, c.allow, w.Header().Get("Allow"), c.method+" "+c.path)
+		}
+	}
+
+	h, params, template, _ := r.Lookup("GET", "/files/abc")
+	assert.NotNil(t, h)
+	assert.Equal(t, map[string]string{"_name": "abc"}, params)
+	assert.Equal(t, "/files/*", template)
+}
+
+func TestRouter_VarConstraintsConflict(t *testing.T) {
+	h := func(w http.ResponseWriter, req *http.Request) {}
+
+	// only one route per method is kept on a node, the second would be
+	// matched with the param names and constraints of the first
+	r := NewRouter()
+	r.Get("/users/{id:[0-9]+}", h)
+	assert.Panics(t, func() { r.Get("/users/{name:[a-z]+}", h) }, "other param name")
+	assert.Panics(t, func() { r.Get("/users/{id:[a-z]+}", h) }, "other constraint")
+	assert.Panics(t, func() { r.Get("/users/{id}", h) }, "no constraint")
+	assert.Panics(t, func() { r.Get("/users/:name", h) }, "other param name")
+
+	assert.NotPanics(t, func() { r.Get("/users/{id:[0-9]+}", h) }, "same route")
+	assert.NotPanics(t, func() { r.Post("/users/{name:[a-z]+}", h) }, "other method")
+
+	r = NewRouter()
+	r.Get("/users/:id", h)
+	assert.Panics(t, func() { r.Get("/users/{id:[0-9]+}", h) }, "constraint added")
+	assert.NotPanics(t, func() { r.Get("/users/:id", h) }, "same route")
+}
+
+func mustNewRequest(method, path string) *http.Request {
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
@@ -5,21 +5,73 @@
 package vestigo
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 )
 
+// CorsMergeMode - How a field of a CORS policy is merged into the policy it is
+// layered on top of, for example a resource policy on top of the global policy
+type CorsMergeMode uint8
+
+const (
+	// CorsMergeAppend - the default, lists are appended to the inherited lists,
+	// flags are turned on when set, and MaxAge overrides the inherited value when set
+	CorsMergeAppend CorsMergeMode = iota
+	// CorsMergeInherit - the field is ignored, and the inherited value is kept
+	CorsMergeInherit
+	// CorsMergeReplace - the field replaces the inherited value, even when empty
+	CorsMergeReplace
+	// CorsMergeDisable - the field is cleared, whatever the inherited value is
+	CorsMergeDisable
+)
+
+// CorsMergeModes - The merge mode of each field of a CORS policy
+type CorsMergeModes struct {
+	AllowOrigin         CorsMergeMode
+	AllowCredentials    CorsMergeMode
+	ExposeHeaders       CorsMergeMode
+	MaxAge              CorsMergeMode
+	AllowMethods        CorsMergeMode
+	AllowHeaders        CorsMergeMode
+	AllowPrivateNetwork CorsMergeMode
+}
+
+// override - layer merge modes on top of these ones, any mode other than the
+// default append mode takes precedence
+func (m CorsMergeModes) override(m2 CorsMergeModes) CorsMergeModes {
+	pick := func(a, b CorsMergeMode) CorsMergeMode {
+		if b != CorsMergeAppend {
+			return b
+		}
+		return a
+	}
+	return CorsMergeModes{
+		AllowOrigin:         pick(m.AllowOrigin, m2.AllowOrigin),
+		AllowCredentials:    pick(m.AllowCredentials, m2.AllowCredentials),
+		ExposeHeaders:       pick(m.ExposeHeaders, m2.ExposeHeaders),
+		MaxAge:              pick(m.MaxAge, m2.MaxAge),
+		AllowMethods:        pick(m.AllowMethods, m2.AllowMethods),
+		AllowHeaders:        pick(m.AllowHeaders, m2.AllowHeaders),
+		AllowPrivateNetwork: pick(m.AllowPrivateNetwork, m2.AllowPrivateNetwork),
+	}
+}
+
 // CorsAccessControl - Default implementation of Cors
 type CorsAccessControl struct {
-	AllowOrigin      []string
-	AllowCredentials bool
-	ExposeHeaders    []string
-	MaxAge           time.Duration
-	AllowMethods     []string
-	AllowHeaders     []string
+	AllowOrigin         []string
+	AllowCredentials    bool
+	ExposeHeaders       []string
+	MaxAge              time.Duration
+	AllowMethods        []string
+	AllowHeaders        []string
+	AllowPrivateNetwork bool
+	// MergeModes - how each field is merged into the policy this one is layered on
+	MergeModes CorsMergeModes
+	// Disabled - turn CORS off entirely wherever this policy applies, no CORS
+	// headers are sent and every preflight is refused
+	Disabled bool
 }
 
 // GetAllowOrigin - returns the allow-origin string representation
@@ -52,7 +104,14 @@ func (c *CorsAccessControl) GetAllowHeaders() []string {
 	return c.AllowHeaders
 }
 
-// Merge - Merge the values of one CORS policy into 'this' one
+// GetAllowPrivateNetwork - returns the allow-private-network string representation
+func (c *CorsAccessControl) GetAllowPrivateNetwork() bool {
+	return c.AllowPrivateNetwork
+}
+
+// Merge - Merge the values of one CORS policy into 'this' one.  How each field
+// of c2 is merged is decided by c2's MergeModes, by default list values are
+// appended, flags are turned on, and MaxAge is overridden when set.
 func (c *CorsAccessControl) Merge(c2 *CorsAccessControl) *CorsAccessControl {
 	result := new(CorsAccessControl)
 	if c != nil {
@@ -63,166 +122,416 @@ func (c *CorsAccessControl) Merge(c2 *CorsAccessControl) *CorsAccessControl {
 			result.MaxAge = c.GetMaxAge()
 			result.AllowMethods = c.GetAllowMethods()
 			result.AllowHeaders = c.GetAllowHeaders()
+			result.AllowPrivateNetwork = c.GetAllowPrivateNetwork()
+			result.MergeModes = c.MergeModes
+			result.Disabled = c.Disabled
 			return result
 		}
+		modes := c2.MergeModes
 
-		if allowOrigin := c2.GetAllowOrigin(); len(allowOrigin) != 0 {
-			result.AllowOrigin = append(c.GetAllowOrigin(), c2.GetAllowOrigin()...)
-		} else {
-			result.AllowOrigin = c.GetAllowOrigin()
-		}
-		if allowCredentials := c2.GetAllowCredentials(); allowCredentials == true {
-			result.AllowCredentials = c2.GetAllowCredentials()
-		} else {
-			result.AllowCredentials = c.GetAllowCredentials()
-		}
-		if exposeHeaders := c2.GetExposeHeaders(); len(exposeHeaders) != 0 {
-			h := append(c.GetExposeHeaders(), c2.GetExposeHeaders()...)
-			seen := map[string]bool{}
-			for i, x := range h {
-				if seen[strings.ToLower(x)] {
-					continue
-				}
-				seen[strings.ToLower(x)] = true
-				result.ExposeHeaders = append(result.ExposeHeaders, h[i])
-			}
-		} else {
-			result.ExposeHeaders = c.GetExposeHeaders()
-		}
-		if maxAge := c2.GetMaxAge(); maxAge.Seconds() != 0 {
-			result.MaxAge = c2.GetMaxAge()
-		} else {
+		result.AllowOrigin = mergeList(modes.AllowOrigin, c.GetAllowOrigin(), c2.GetAllowOrigin(), false)
+		result.AllowCredentials = mergeFlag(modes.AllowCredentials, c.GetAllowCredentials(), c2.GetAllowCredentials())
+		result.ExposeHeaders = mergeList(modes.ExposeHeaders, c.GetExposeHeaders(), c2.GetExposeHeaders(), true)
+		switch modes.MaxAge {
+		case CorsMergeInherit:
 			result.MaxAge = c.GetMaxAge()
-		}
-		if allowMethods := c2.GetAllowMethods(); len(allowMethods) != 0 {
-			h := append(c.GetAllowMethods(), allowMethods...)
-			seen := map[string]bool{}
-			for i, x := range h {
-				if seen[x] {
-					continue
-				}
-				seen[x] = true
-				result.AllowMethods = append(result.AllowMethods, h[i])
+		case CorsMergeReplace:
+			result.MaxAge = c2.GetMaxAge()
+		case CorsMergeDisable:
+			result.MaxAge = 0
+		default:
+			if maxAge := c2.GetMaxAge(); maxAge.Seconds() != 0 {
+				result.MaxAge = c2.GetMaxAge()
+			} else {
+				result.MaxAge = c.GetMaxAge()
 			}
-		} else {
-			result.AllowMethods = c.GetAllowMethods()
 		}
-		if allowHeaders := c2.GetAllowHeaders(); len(allowHeaders) != 0 {
-			h := append(c.GetAllowHeaders(), c2.GetAllowHeaders()...)
-			seen := map[string]bool{}
-			for i, x := range h {
-				if seen[strings.ToLower(x)] {
-					continue
-				}
-				seen[strings.ToLower(x)] = true
-				result.AllowHeaders = append(result.AllowHeaders, h[i])
+		result.AllowMethods = mergeList(modes.AllowMethods, c.GetAllowMethods(), c2.GetAllowMethods(), false)
+		result.AllowHeaders = mergeList(modes.AllowHeaders, c.GetAllowHeaders(), c2.GetAllowHeaders(), true)
+		result.AllowPrivateNetwork = mergeFlag(modes.AllowPrivateNetwork, c.GetAllowPrivateNetwork(), c2.GetAllowPrivateNetwork())
+
+		// keep the modes around, so a resource policy that is itself built up
+		// from several policies still overrides the global policy as asked
+		result.MergeModes = c.MergeModes.override(modes)
+		result.Disabled = c.Disabled || c2.Disabled
+	}
+	return result
+}
+
+// mergeList - merge a list field of a CORS policy according to the merge mode,
+// values are deduplicated, case insensitively if fold is set
+func mergeList(mode CorsMergeMode, inherited, local []string, fold bool) []string {
+	switch mode {
+	case CorsMergeInherit:
+		return inherited
+	case CorsMergeReplace:
+		return local
+	case CorsMergeDisable:
+		return nil
+	}
+	if len(local) == 0 {
+		return inherited
+	}
+	var result []string
+	seen := map[string]bool{}
+	for _, list := range [][]string{inherited, local} {
+		for _, x := range list {
+			key := x
+			if fold {
+				key = strings.ToLower(x)
 			}
-		} else {
-			result.AllowHeaders = c.GetAllowHeaders()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, x)
 		}
 	}
 	return result
 }
 
+// mergeFlag - merge a boolean field of a CORS policy according to the merge mode
+func mergeFlag(mode CorsMergeMode, inherited, local bool) bool {
+	switch mode {
+	case CorsMergeInherit:
+		return inherited
+	case CorsMergeReplace:
+		return local
+	case CorsMergeDisable:
+		return false
+	}
+	return inherited || local
+}
+
+// CorsRejectReason - the reason a CORS preflight request was refused
+type CorsRejectReason int
+
+const (
+	// CorsOriginNotAllowed - the request Origin is not in the allowed origins
+	CorsOriginNotAllowed CorsRejectReason = iota + 1
+	// CorsMethodNotAllowed - the Access-Control-Request-Method is not allowed
+	CorsMethodNotAllowed
+	// CorsHeaderNotAllowed - one of the Access-Control-Request-Headers is not allowed
+	CorsHeaderNotAllowed
+	// CorsPrivateNetworkNotAllowed - private network access was requested but is not allowed
+	CorsPrivateNetworkNotAllowed
+	// CorsDisabled - CORS is disabled for the resource
+	CorsDisabled
+)
+
+// String - returns the string representation of the reject reason
+func (c CorsRejectReason) String() string {
+	switch c {
+	case CorsOriginNotAllowed:
+		return "origin not allowed"
+	case CorsMethodNotAllowed:
+		return "method not allowed"
+	case CorsHeaderNotAllowed:
+		return "header not allowed"
+	case CorsPrivateNetworkNotAllowed:
+		return "private network not allowed"
+	case CorsDisabled:
+		return "cors disabled"
+	}
+	return "unknown"
+}
+
+// CorsRejection - describes a refused CORS preflight request
+type CorsRejection struct {
+	Reason   CorsRejectReason
+	Template string
+	Origin   string
+	Method   string
+	Headers  []string
+}
+
+// Error - implementation of error, making CorsRejection an error
+func (c *CorsRejection) Error() string {
+	msg := "cors preflight rejected: " + c.Reason.String()
+	switch c.Reason {
+	case CorsOriginNotAllowed:
+		msg += " (" + c.Origin + ")"
+	case CorsMethodNotAllowed:
+		msg += " (" + c.Method + ")"
+	case CorsHeaderNotAllowed:
+		msg += " (" + strings.Join(c.Headers, ", ") + ")"
+	}
+	if c.Template != "" {
+		msg += " for " + c.Template
+	}
+	return msg
+}
+
 // corsPreflight - perform CORS preflight against the CORS policy for a given resource
-func corsPreflight(gcors *CorsAccessControl, lcors *CorsAccessControl, allowedMethods string, w http.ResponseWriter, r *http.Request) error {
-
-	cors := gcors.Merge(lcors)
-
-	if origin := r.Header.Get("Origin"); cors != nil && origin != "" {
-		// validate origin is in list of acceptable allow-origins
-		allowedOrigin := false
-		allowedOriginExact := false
-		for _, v := range cors.GetAllowOrigin() {
-			if v == origin {
-				w.Header().Add("Access-Control-Allow-Origin", origin)
-				allowedOriginExact = true
-				allowedOrigin = true
-				break
-			}
+func corsPreflight(router *Router, cors *CorsAccessControl, allowed methodSet, template string, w http.ResponseWriter, r *http.Request) error {
+	header, err := evaluateCors(cors, allowed, r, true)
+	setHeaders(w.Header(), header)
+	if err != nil {
+		err.Template = template
+		if router.onCorsReject != nil {
+			router.onCorsReject(r, err)
 		}
-		if !allowedOrigin {
-			for _, v := range cors.GetAllowOrigin() {
-				if v == "*" {
-					w.Header().Add("Access-Control-Allow-Origin", v)
-					allowedOrigin = true
-					break
-				}
-			}
+		status := http.StatusOK
+		if router.corsRejectStatus != 0 {
+			status = router.corsRejectStatus
 		}
+		w.WriteHeader(status)
+		w.Write([]byte(""))
+		return err
+	}
+	return nil
+}
 
-		if !allowedOrigin {
-			// other option headers needed
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(""))
-			return errors.New("quick cors end")
+// evaluateCors - evaluate a request against a CORS policy, and return the
+// CORS response headers for it.  Preflight requests are answered with the
+// allow-methods, allow-headers and max-age headers, while actual requests
+// are answered with the expose-headers header.  A rejection is returned when
+// a preflight request should be refused, in which case only the Vary header
+// is returned.
+func evaluateCors(cors *CorsAccessControl, methodsAllowed methodSet, r *http.Request, preflight bool) (http.Header, *CorsRejection) {
+	header := http.Header{}
+	if cors == nil {
+		return header, nil
+	}
+	if cors.Disabled {
+		if origin := r.Header.Get("Origin"); preflight && origin != "" {
+			return header, &CorsRejection{Reason: CorsDisabled, Origin: origin}
+		}
+		return header, nil
+	}
 
+	if cors.variesByOrigin() {
+		// the allow-origin header echoes the request origin, so shared
+		// caches must not hand this response to a different origin
+		header["Vary"] = []string{"Origin"}
+	}
+	if preflight {
+		// a preflight answer echoes the method and headers the browser is
+		// asking for, whatever the origins allowed
+		header["Vary"] = append(header["Vary"], "Access-Control-Request-Method", "Access-Control-Request-Headers")
+	}
+	if preflight && cors.GetAllowPrivateNetwork() {
+		header["Vary"] = append(header["Vary"], "Access-Control-Request-Private-Network")
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return header, nil
+	}
+
+	// validate origin is in list of acceptable allow-origins
+	allowOrigin := ""
+	for _, v := range cors.GetAllowOrigin() {
+		if v == origin {
+			allowOrigin = origin
+			break
+		}
+		if v == "*" {
+			allowOrigin = v
+		}
+	}
+	if allowOrigin == "" {
+		if preflight {
+			return header, &CorsRejection{Reason: CorsOriginNotAllowed, Origin: origin}
 		}
+		return header, nil
+	}
+	header.Set("Access-Control-Allow-Origin", allowOrigin)
+
+	// if allow credentials is allowed on this resource respond with true
+	if cors.GetAllowCredentials() && allowOrigin != "*" {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if exposeHeaders := cors.GetExposeHeaders(); len(exposeHeaders) != 0 {
+			// if we have expose headers, send them
+			header.Set("Access-Control-Expose-Headers", strings.Join(exposeHeaders, ", "))
+		}
+		return header, nil
+	}
 
-		// if the request includes access-control-request-method
-		if method := r.Header.Get("Access-Control-Request-Method"); method != "" {
-			// if there are no cors settings for this resource, use the allowedMethods,
-			// if there are settings for cors, use those
-			responseMethods := []string{}
-			if methods := cors.GetAllowMethods(); len(methods) != 0 {
-				for _, x := range methods {
-					if x == method {
-						responseMethods = append(responseMethods, x)
-					}
+	// if the request includes access-control-request-method
+	method := r.Header.Get("Access-Control-Request-Method")
+	if method != "" {
+		// if there are no cors settings for this resource, use the allowed
+		// methods of the resource, if there are settings for cors, use those
+		var allowed bool
+		if methods := cors.GetAllowMethods(); len(methods) == 0 {
+			allowed = methodsAllowed.has(method)
+		} else {
+			for _, x := range methods {
+				if x == method {
+					allowed = true
+					break
 				}
-			} else {
-				for _, x := range strings.Split(allowedMethods, ", ") {
-					if x == method {
-						responseMethods = append(responseMethods, x)
-					}
+			}
+		}
+		if !allowed {
+			return onlyVary(header), &CorsRejection{Reason: CorsMethodNotAllowed, Origin: origin, Method: method}
+		}
+		header.Set("Access-Control-Allow-Methods", method)
+	}
+
+	if maxAge := cors.GetMaxAge(); maxAge.Seconds() != 0 {
+		// optional, if we have a max age, send it
+		header.Set("Access-Control-Max-Age", fmt.Sprint(int64(maxAge.Seconds())))
+	}
+
+	if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+		requested = strings.Replace(requested, " ", "", -1)
+		goodHeaders := []string{}
+		badHeaders := []string{}
+		for _, x := range strings.Split(requested, ",") {
+			if x == "" {
+				continue
+			}
+			good := false
+			for _, y := range cors.GetAllowHeaders() {
+				if strings.ToLower(x) == strings.ToLower(y) {
+					good = true
+					break
 				}
 			}
-			if len(responseMethods) > 0 {
-				w.Header().Add("Access-Control-Allow-Methods", strings.Join(responseMethods, ", "))
+			if good {
+				goodHeaders = append(goodHeaders, x)
 			} else {
-				// other option headers needed
-				w.WriteHeader(http.StatusOK)
-				w.Write([]byte(""))
-				return errors.New("quick cors end")
+				badHeaders = append(badHeaders, x)
 			}
 		}
+		if len(badHeaders) > 0 {
+			return onlyVary(header), &CorsRejection{Reason: CorsHeaderNotAllowed, Origin: origin, Method: method, Headers: badHeaders}
+		}
+		if len(goodHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(goodHeaders, ", "))
+		}
+	}
+
+	// private network access preflights need an explicit opt in from the policy
+	if r.Header.Get("Access-Control-Request-Private-Network") == "true" {
+		if !cors.GetAllowPrivateNetwork() {
+			return onlyVary(header), &CorsRejection{Reason: CorsPrivateNetworkNotAllowed, Origin: origin, Method: method}
+		}
+		header.Set("Access-Control-Allow-Private-Network", "true")
+	}
+	return header, nil
+}
+
+// flightCors - the CORS response headers of a policy for actual, not preflight,
+// requests, which only depend on the origin of the request, worked out ahead of
+// time by evaluating the policy once per origin it lists
+type flightCors struct {
+	// none - for requests without an origin, other - for origins the
+	// policy does not list
+	none, other http.Header
+	origins     map[string]http.Header
+}
 
-		// if allow credentials is allowed on this resource respond with true
-		if allowCredentials := cors.GetAllowCredentials(); allowedOriginExact && allowCredentials {
-			w.Header().Add("Access-Control-Allow-Credentials", "true")
+// newFlightCors - work out the headers of a policy for actual requests
+func newFlightCors(cors *CorsAccessControl) *flightCors {
+	evaluate := func(origin string) http.Header {
+		r := &http.Request{Header: http.Header{}}
+		if origin != "" {
+			r.Header.Set("Origin", origin)
 		}
+		header, _ := evaluateCors(cors, 0, r, false)
+		return header
+	}
+	// an origin of "*" is only allowed by "*", as any origin not listed is
+	f := &flightCors{none: evaluate(""), other: evaluate("*"), origins: map[string]http.Header{}}
+	if cors != nil {
+		for _, origin := range cors.GetAllowOrigin() {
+			if origin != "*" {
+				f.origins[origin] = evaluate(origin)
+			}
+		}
+	}
+	return f
+}
 
-		if exposeHeaders := cors.GetExposeHeaders(); len(exposeHeaders) != 0 {
-			// if we have expose headers, send them
-			w.Header().Add("Access-Control-Expose-Headers", strings.Join(exposeHeaders, ", "))
+// wrap - a handler setting the headers on the response before calling h, which
+// is h itself when the policy never sets any
+func (f *flightCors) wrap(h http.HandlerFunc) http.HandlerFunc {
+	empty := len(f.none) == 0 && len(f.other) == 0
+	for _, header := range f.origins {
+		empty = empty && len(header) == 0
+	}
+	if empty {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := f.other
+		if origin := r.Header.Get("Origin"); origin == "" {
+			header = f.none
+		} else if listed, ok := f.origins[origin]; ok {
+			header = listed
 		}
-		if maxAge := cors.GetMaxAge(); maxAge.Seconds() != 0 {
-			// optional, if we have a max age, send it
-			sec := fmt.Sprint(int64(maxAge.Seconds()))
-			w.Header().Add("Access-Control-Max-Age", sec)
+		setHeaders(w.Header(), header)
+		h(w, r)
+	}
+}
+
+// onlyVary - strip every header but Vary from a CORS response header set
+func onlyVary(header http.Header) http.Header {
+	for k := range header {
+		if k != "Vary" {
+			delete(header, k)
 		}
+	}
+	return header
+}
 
-		if header := r.Header.Get("Access-Control-Request-Headers"); header != "" {
-			header = strings.Replace(header, " ", "", -1)
-			requestHeaders := strings.Split(header, ",")
+// setHeaders - copy the CORS response headers onto the response, replacing
+// any values already there so the response never carries duplicates
+func setHeaders(dst, src http.Header) {
+	for k, v := range src {
+		if k == "Vary" {
+			addVary(dst, v...)
+			continue
+		}
+		dst[k] = v
+	}
+}
 
-			allowHeaders := cors.GetAllowHeaders()
+// variesByOrigin - does the response to a request depend on the request's Origin.
+// A policy that only allows "*" answers every origin the same way, so there is
+// nothing for a cache to vary on.
+func (c *CorsAccessControl) variesByOrigin() bool {
+	if c == nil {
+		return false
+	}
+	for _, v := range c.GetAllowOrigin() {
+		if v != "*" {
+			return true
+		}
+	}
+	return false
+}
 
-			goodHeaders := []string{}
+// addVary - add values to the Vary header, skipping any that are already present
+func addVary(h http.Header, values ...string) {
+	for _, v := range values {
+		if !varies(h["Vary"], v) {
+			h.Add("Vary", v)
+		}
+	}
+}
 
-			for _, x := range requestHeaders {
-				for _, y := range allowHeaders {
-					if strings.ToLower(x) == strings.ToLower(y) {
-						goodHeaders = append(goodHeaders, x)
-						break
-					}
-				}
+// varies - whether the lines of a Vary header list the value, or "*", which
+// covers every value
+func varies(lines []string, value string) bool {
+	for _, line := range lines {
+		for line != "" {
+			field := line
+			if i := strings.IndexByte(line, ','); i >= 0 {
+				field, line = line[:i], line[i+1:]
+			} else {
+				line = ""
 			}
-
-			if len(goodHeaders) > 0 {
-				w.Header().Add("Access-Control-Allow-Headers", strings.Join(goodHeaders, ", "))
+			if field = strings.TrimSpace(field); field == "*" || strings.EqualFold(field, value) {
+				return true
 			}
 		}
 	}
-	return nil
+	return false
 }
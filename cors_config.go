@@ -0,0 +1,319 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CorsConfig - A declarative CORS configuration document.  Global replaces the
+// policy set with SetGlobalCors, and the policies in Paths are layered on top of
+// the policies set in code for the route template they are keyed by.
+//
+//	{
+//		"global": {"allowOrigin": ["https://example.com"], "maxAge": "1h"},
+//		"paths": {
+//			"/users/:id": {
+//				"policy": {"allowCredentials": true},
+//				"methods": {"DELETE": {"disabled": true}}
+//			}
+//		}
+//	}
+type CorsConfig struct {
+	Global *CorsAccessControl
+	Paths  map[string]*CorsPathConfig
+}
+
+// CorsPathConfig - The CORS policies of a single route template in a CorsConfig
+type CorsPathConfig struct {
+	Policy  *CorsAccessControl
+	Methods map[string]*CorsAccessControl
+}
+
+// ParseCorsConfig - Parse a JSON CORS configuration document.  Unknown fields are
+// refused, so a typo in a policy does not silently loosen it.
+func ParseCorsConfig(r io.Reader) (*CorsConfig, error) {
+	doc := new(corsConfigDoc)
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("cors config: %v", err)
+	}
+	c, err := doc.config()
+	if err != nil {
+		return nil, fmt.Errorf("cors config: %v", err)
+	}
+	return c, nil
+}
+
+// Validate - Validate the configuration against the routes registered on the
+// router, every path must be a registered route template, and every policy must
+// be well formed.
+func (c *CorsConfig) Validate(r *Router) error {
+	if err := c.Global.validate(); err != nil {
+		return fmt.Errorf("cors config: global: %v", err)
+	}
+	paths := make([]string, 0, len(c.Paths))
+	for path := range c.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if !r.templates[path] {
+			return fmt.Errorf("cors config: %s: not a registered route", path)
+		}
+		pc := c.Paths[path]
+		if pc == nil {
+			continue
+		}
+		if err := pc.Policy.validate(); err != nil {
+			return fmt.Errorf("cors config: %s: %v", path, err)
+		}
+		for method, policy := range pc.Methods {
+			if !validMethod(method) {
+				return fmt.Errorf("cors config: %s: invalid method %s", path, method)
+			}
+			if err := policy.validate(); err != nil {
+				return fmt.Errorf("cors config: %s %s: %v", method, path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReloadCors - Load a JSON CORS configuration document (see CorsConfig), and
+// once it is parsed and validated, swap it in for the previously loaded one.
+// Requests in flight keep the policy they started with.  Nothing is changed
+// if an error is returned.
+func (r *Router) ReloadCors(reader io.Reader) error {
+	c, err := ParseCorsConfig(reader)
+	if err != nil {
+		return err
+	}
+	if err := c.Validate(r); err != nil {
+		return err
+	}
+	r.corsMu.Lock()
+	defer r.corsMu.Unlock()
+	state := &corsState{config: c}
+	if r.compiled {
+		// requests only ever see the configuration together with the
+		// policies and routes worked out from it
+		state = r.compileState(c)
+	}
+	r.cors.Store(state)
+	return nil
+}
+
+// corsState - a loaded CORS configuration, and the policies and routes of every
+// route and method worked out from it when the router is compiled
+type corsState struct {
+	config   *CorsConfig
+	policies map[corsKey]*CorsAccessControl
+	routes   []compiledRoute
+}
+
+// compileState - work out the policies and routes of the CORS configuration c
+func (r *Router) compileState(c *CorsConfig) *corsState {
+	policies := r.compileCors(c)
+	return &corsState{config: c, policies: policies, routes: r.compileRoutes(policies)}
+}
+
+// corsState - the CORS configuration in effect, empty when none is loaded
+func (r *Router) corsState() *corsState {
+	if s, _ := r.cors.Load().(*corsState); s != nil {
+		return s
+	}
+	return &corsState{}
+}
+
+// corsPolicy - the CORS policy in effect for a method on a matched resource,
+// worked out ahead of time when the router is compiled
+func (r *Router) corsPolicy(res *resource, template, method string) *CorsAccessControl {
+	s := r.corsState()
+	if c, ok := s.policies[corsKey{res, method, template}]; ok {
+		return c
+	}
+	return r.mergedCorsPolicy(s.config, res, template, method)
+}
+
+// mergedCorsPolicy - the CORS policy in effect for a method on a matched
+// resource, which is the resource policy layered on the global policy, with the
+// CORS configuration c, if any, taken into account
+func (r *Router) mergedCorsPolicy(c *CorsConfig, res *resource, template, method string) *CorsAccessControl {
+	global, local := r.globalCors, res.corsFor(method)
+	if c != nil {
+		if c.Global != nil {
+			global = c.Global
+		}
+		if pc := c.Paths[template]; pc != nil {
+			local = local.Merge(pc.Policy)
+			if method == http.MethodHead {
+				method = http.MethodGet
+			}
+			if mc, ok := pc.Methods[method]; ok {
+				local = local.Merge(mc)
+			}
+		}
+	}
+	return global.Merge(local)
+}
+
+// validate - check that a policy is well formed
+func (c *CorsAccessControl) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, origin := range c.AllowOrigin {
+		if origin == "" || strings.ContainsAny(origin, " \t,") {
+			return fmt.Errorf("invalid allow origin %q", origin)
+		}
+	}
+	for _, method := range c.AllowMethods {
+		if !validMethod(method) {
+			return fmt.Errorf("invalid allow method %q", method)
+		}
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("negative max age %s", c.MaxAge)
+	}
+	return nil
+}
+
+// corsMergeModeNames - the names of the merge modes in a configuration document
+var corsMergeModeNames = map[CorsMergeMode]string{
+	CorsMergeAppend:  "append",
+	CorsMergeInherit: "inherit",
+	CorsMergeReplace: "replace",
+	CorsMergeDisable: "disable",
+}
+
+// corsConfigDoc - the JSON document of a CorsConfig, see ParseCorsConfig
+type corsConfigDoc struct {
+	Global *corsPolicyDoc          `json:"global"`
+	Paths  map[string]*corsPathDoc `json:"paths"`
+}
+
+// corsPathDoc - the JSON document of a CorsPathConfig
+type corsPathDoc struct {
+	Policy  *corsPolicyDoc            `json:"policy"`
+	Methods map[string]*corsPolicyDoc `json:"methods"`
+}
+
+// corsPolicyDoc - the JSON document of a CorsAccessControl, with the max age as
+// a duration string such as "1h30m", or a number of seconds, and the merge modes
+// by name
+type corsPolicyDoc struct {
+	AllowOrigin         []string          `json:"allowOrigin"`
+	AllowCredentials    bool              `json:"allowCredentials"`
+	ExposeHeaders       []string          `json:"exposeHeaders"`
+	MaxAge              json.RawMessage   `json:"maxAge"`
+	AllowMethods        []string          `json:"allowMethods"`
+	AllowHeaders        []string          `json:"allowHeaders"`
+	AllowPrivateNetwork bool              `json:"allowPrivateNetwork"`
+	MergeModes          map[string]string `json:"mergeModes"`
+	Disabled            bool              `json:"disabled"`
+}
+
+// config - the CorsConfig of the document
+func (doc *corsConfigDoc) config() (*CorsConfig, error) {
+	c := new(CorsConfig)
+	var err error
+	if c.Global, err = doc.Global.policy(); err != nil {
+		return nil, fmt.Errorf("global: %v", err)
+	}
+	if doc.Paths != nil {
+		c.Paths = make(map[string]*CorsPathConfig, len(doc.Paths))
+	}
+	for path, pd := range doc.Paths {
+		if pd == nil {
+			c.Paths[path] = nil
+			continue
+		}
+		pc := new(CorsPathConfig)
+		if pc.Policy, err = pd.Policy.policy(); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if pd.Methods != nil {
+			pc.Methods = make(map[string]*CorsAccessControl, len(pd.Methods))
+		}
+		for method, md := range pd.Methods {
+			if pc.Methods[method], err = md.policy(); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", method, path, err)
+			}
+		}
+		c.Paths[path] = pc
+	}
+	return c, nil
+}
+
+// policy - the CorsAccessControl of the document
+func (doc *corsPolicyDoc) policy() (*CorsAccessControl, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	c := &CorsAccessControl{
+		AllowOrigin:         doc.AllowOrigin,
+		AllowCredentials:    doc.AllowCredentials,
+		ExposeHeaders:       doc.ExposeHeaders,
+		AllowMethods:        doc.AllowMethods,
+		AllowHeaders:        doc.AllowHeaders,
+		AllowPrivateNetwork: doc.AllowPrivateNetwork,
+		Disabled:            doc.Disabled,
+	}
+	if len(doc.MaxAge) > 0 {
+		var maxAge interface{}
+		if err := json.Unmarshal(doc.MaxAge, &maxAge); err != nil {
+			return nil, err
+		}
+		switch v := maxAge.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max age: %v", err)
+			}
+			c.MaxAge = d
+		case float64:
+			c.MaxAge = time.Duration(v * float64(time.Second))
+		default:
+			return nil, fmt.Errorf("invalid max age: %s", doc.MaxAge)
+		}
+	}
+	modes := map[string]*CorsMergeMode{
+		"allowOrigin":         &c.MergeModes.AllowOrigin,
+		"allowCredentials":    &c.MergeModes.AllowCredentials,
+		"exposeHeaders":       &c.MergeModes.ExposeHeaders,
+		"maxAge":              &c.MergeModes.MaxAge,
+		"allowMethods":        &c.MergeModes.AllowMethods,
+		"allowHeaders":        &c.MergeModes.AllowHeaders,
+		"allowPrivateNetwork": &c.MergeModes.AllowPrivateNetwork,
+	}
+	for field, name := range doc.MergeModes {
+		m, ok := modes[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown merge mode field %q", field)
+		}
+		if *m, ok = parseCorsMergeMode(name); !ok {
+			return nil, fmt.Errorf("invalid merge mode %q", name)
+		}
+	}
+	return c, nil
+}
+
+// parseCorsMergeMode - the merge mode of a name in a configuration document
+func parseCorsMergeMode(name string) (CorsMergeMode, bool) {
+	for mode, n := range corsMergeModeNames {
+		if n == name {
+			return mode, true
+		}
+	}
+	return 0, false
+}
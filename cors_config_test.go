@@ -0,0 +1,167 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCorsConfig(t *testing.T) {
+	c, err := ParseCorsConfig(strings.NewReader(`{
+		"global": {"allowOrigin": ["test.com"], "maxAge": "1h30m"},
+		"paths": {
+			"/users/:id": {
+				"policy": {"allowOrigin": ["admin.test.com"], "maxAge": 60, "mergeModes": {"allowOrigin": "replace"}},
+				"methods": {"DELETE": {"disabled": true}}
+			}
+		}
+	}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 90*time.Minute, c.Global.MaxAge)
+		assert.Equal(t, time.Minute, c.Paths["/users/:id"].Policy.MaxAge)
+		assert.Equal(t, CorsMergeReplace, c.Paths["/users/:id"].Policy.MergeModes.AllowOrigin)
+		assert.True(t, c.Paths["/users/:id"].Methods["DELETE"].Disabled)
+	}
+
+	for _, doc := range []string{
+		`{"global": {"allowOrigins": ["test.com"]}}`,
+		`{"global": {"maxAge": "forever"}}`,
+		`{"global": {"mergeModes": {"allowOrigin": "overwrite"}}}`,
+		`{"global": {"mergeModes": {"allowOrigins": "replace"}}}`,
+		`{"global": `,
+	} {
+		_, err := ParseCorsConfig(strings.NewReader(doc))
+		assert.Error(t, err, doc)
+	}
+}
+
+func TestCorsPolicyJSONUnchanged(t *testing.T) {
+	// the configuration document format is ParseCorsConfig's own, the JSON of
+	// the policy type itself is left alone
+	c := &CorsAccessControl{
+		AllowOrigin: []string{"test.com"},
+		MaxAge:      time.Hour,
+	}
+	b, err := json.Marshal(c)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(b), `"AllowOrigin":["test.com"]`)
+		assert.Contains(t, string(b), `"MaxAge":3600000000000`)
+
+		c2 := new(CorsAccessControl)
+		assert.NoError(t, json.Unmarshal(b, c2))
+		assert.Equal(t, c, c2)
+	}
+
+	c2 := new(CorsAccessControl)
+	assert.NoError(t, json.Unmarshal([]byte(`{"MaxAge": 3600000000000, "Unknown": true}`), c2))
+	assert.Equal(t, time.Hour, c2.MaxAge)
+}
+
+func TestCorsConfigValidate(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+
+	for doc, ok := range map[string]bool{
+		`{"paths": {"/users/:id": {"policy": {"allowOrigin": ["test.com"]}}}}`:   true,
+		`{"paths": {"/users/:name": {"policy": {"allowOrigin": ["test.com"]}}}}`: false,
+		`{"paths": {"/users/:id": {"methods": {"FETCH": {}}}}}`:                  false,
+		`{"paths": {"/users/:id": {"methods": {"GET": {"maxAge": -1}}}}}`:        false,
+		`{"global": {"allowOrigin": [""]}}`:                                      false,
+		`{"global": {"allowMethods": ["get"]}}`:                                  false,
+	} {
+		c, err := ParseCorsConfig(strings.NewReader(doc))
+		if !assert.NoError(t, err, doc) {
+			continue
+		}
+		if ok {
+			assert.NoError(t, c.Validate(router), doc)
+		} else {
+			assert.Error(t, c.Validate(router), doc)
+		}
+	}
+}
+
+func TestRouterReloadCors(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin: []string{"test.com"},
+	})
+	router.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+
+	allowOrigin := func(origin string) string {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/users/1", nil)
+		r.Header.Add("Origin", origin)
+		router.ServeHTTP(w, r)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	assert.Equal(t, "test.com", allowOrigin("test.com"))
+	assert.Equal(t, "", allowOrigin("other.com"))
+
+	err := router.ReloadCors(strings.NewReader(`{
+		"global": {"allowOrigin": ["other.com"]},
+		"paths": {"/users/:id": {"policy": {"allowOrigin": ["admin.com"]}}}
+	}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", allowOrigin("test.com"))
+		assert.Equal(t, "other.com", allowOrigin("other.com"))
+		assert.Equal(t, "admin.com", allowOrigin("admin.com"))
+	}
+
+	// a bad document leaves the loaded policies in place
+	err = router.ReloadCors(strings.NewReader(`{"paths": {"/nope": {}}}`))
+	if assert.Error(t, err) {
+		assert.Equal(t, "other.com", allowOrigin("other.com"))
+	}
+
+	// an empty document falls back to the policies set in code
+	if assert.NoError(t, router.ReloadCors(strings.NewReader(`{}`))) {
+		assert.Equal(t, "test.com", allowOrigin("test.com"))
+	}
+}
+
+func TestRouterReloadCorsCompiled(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	assert.NoError(t, router.Compile())
+
+	allowOrigin := func() string {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/users/1", nil)
+		r.Header.Add("Origin", "other.com")
+		router.ServeHTTP(w, r)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	// requests see either document whole while it is swapped
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			doc := `{}`
+			if i%2 == 0 {
+				doc = `{"global": {"allowOrigin": ["other.com"]}}`
+			}
+			assert.NoError(t, router.ReloadCors(strings.NewReader(doc)))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if origin := allowOrigin(); origin != "" && origin != "other.com" {
+			t.Errorf("unexpected allow origin %q", origin)
+		}
+	}
+	<-done
+
+	assert.NoError(t, router.ReloadCors(strings.NewReader(`{"global": {"allowOrigin": ["other.com"]}}`)))
+	assert.Equal(t, "other.com", allowOrigin())
+}
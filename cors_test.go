@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCorsFlightWildcardOrigin(t *testing.T) {
@@ -395,3 +397,449 @@ func TestCorsMerge(t *testing.T) {
 		t.Error("should have deduplicated allow methods from c2")
 	}
 }
+
+func TestCorsFlightVaryOrigin(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:      []string{"test.com"},
+		AllowCredentials: true,
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	})
+
+	for _, origin := range []string{"test.com", "badtest.com", ""} {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", path, nil)
+		if origin != "" {
+			r.Header.Add("Origin", origin)
+		}
+		router.ServeHTTP(w, r)
+		if vary := w.Header()["Vary"]; len(vary) != 1 || vary[0] != "Origin" {
+			t.Errorf("expected Vary: Origin for origin %q, got %v", origin, vary)
+		}
+	}
+}
+
+func TestCorsFlightWildcardOnlyNoVary(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin: []string{"*"},
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", path, nil)
+	r.Header.Add("Origin", "test.com")
+	router.ServeHTTP(w, r)
+	if vary := w.Header().Get("Vary"); vary != "" {
+		t.Errorf("wildcard only policy should not vary, got %q", vary)
+	}
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "*" {
+		t.Errorf("expected wildcard allow origin, got %q", origin)
+	}
+}
+
+func TestCorsPreflightVary(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:  []string{"test.com"},
+		AllowHeaders: []string{"X-Header"},
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", path, nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	r.Header.Add("Access-Control-Request-Headers", "X-Header")
+	router.ServeHTTP(w, r)
+
+	vary := strings.Join(w.Header()["Vary"], ", ")
+	if vary != "Origin, Access-Control-Request-Method, Access-Control-Request-Headers" {
+		t.Errorf("unexpected preflight Vary header: %q", vary)
+	}
+}
+
+func TestCorsPreflightWildcardVary(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:  []string{"*"},
+		AllowHeaders: []string{"X-Header"},
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", path, nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	r.Header.Add("Access-Control-Request-Headers", "X-Header")
+	router.ServeHTTP(w, r)
+
+	// the answer echoes the method and headers asked for, but not the origin
+	vary := strings.Join(w.Header()["Vary"], ", ")
+	if vary != "Access-Control-Request-Method, Access-Control-Request-Headers" {
+		t.Errorf("unexpected wildcard preflight Vary header: %q", vary)
+	}
+}
+
+func TestCorsNoDuplicateHeaders(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:      []string{"test.com"},
+		AllowCredentials: true,
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	})
+
+	// outer middleware that already handles some of the CORS headers itself
+	outer := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding, origin")
+		w.Header().Add("Access-Control-Allow-Origin", "test.com")
+		w.Header().Add("Access-Control-Allow-Credentials", "true")
+		router.ServeHTTP(w, r)
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", path, nil)
+	r.Header.Add("Origin", "test.com")
+	outer(w, r)
+
+	for _, h := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials", "Vary"} {
+		if l := len(w.Header()[h]); l != 1 {
+			t.Errorf("expected a single %s header, got %d: %v", h, l, w.Header()[h])
+		}
+	}
+}
+
+func TestCorsFlightExposeHeaders(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:   []string{"test.com"},
+		ExposeHeaders: []string{"X-Header"},
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	})
+	router.SetCors(path, &CorsAccessControl{
+		ExposeHeaders: []string{"X-Y-Header"},
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", path, nil)
+	r.Header.Add("Origin", "test.com")
+	router.ServeHTTP(w, r)
+	if expose := w.Header().Get("Access-Control-Expose-Headers"); expose != "X-Header, X-Y-Header" {
+		t.Errorf("expected expose headers on actual response, got %q", expose)
+	}
+
+	// preflight requests are not the place for expose headers
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest("OPTIONS", path, nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	router.ServeHTTP(w, r)
+	if expose := w.Header().Get("Access-Control-Expose-Headers"); expose != "" {
+		t.Errorf("expected no expose headers on preflight response, got %q", expose)
+	}
+	if methods := w.Header().Get("Access-Control-Allow-Methods"); methods != "GET" {
+		t.Errorf("expected allow methods on preflight response, got %q", methods)
+	}
+}
+
+func TestFailCorsPreflightNoAllowOrigin(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:  []string{"test.com"},
+		AllowMethods: []string{"POST"},
+	})
+
+	path := "/test"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+	router.Post(path, func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", path, nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	router.ServeHTTP(w, r)
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Errorf("failed preflight should not allow the origin, got %q", origin)
+	}
+	if w.Header().Get("Vary") == "" {
+		t.Error("failed preflight should still carry the Vary header")
+	}
+}
+
+func TestCorsPreflightRejection(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:  []string{"test.com"},
+		AllowMethods: []string{"GET"},
+		AllowHeaders: []string{"X-Header"},
+	})
+	router.SetCorsRejectStatus(http.StatusForbidden)
+
+	var rejections []*CorsRejection
+	router.OnCorsReject(func(r *http.Request, rejection *CorsRejection) {
+		rejections = append(rejections, rejection)
+	})
+
+	path := "/users/:id"
+	router.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+	router.Post(path, func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		origin  string
+		method  string
+		headers string
+		reason  CorsRejectReason
+	}{
+		{"badtest.com", "GET", "", CorsOriginNotAllowed},
+		{"test.com", "POST", "", CorsMethodNotAllowed},
+		{"test.com", "GET", "X-Header, X-Other", CorsHeaderNotAllowed},
+	}
+
+	for _, tt := range tests {
+		rejections = nil
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("OPTIONS", "/users/1", nil)
+		r.Header.Add("Origin", tt.origin)
+		r.Header.Add("Access-Control-Request-Method", tt.method)
+		if tt.headers != "" {
+			r.Header.Add("Access-Control-Request-Headers", tt.headers)
+		}
+		router.ServeHTTP(w, r)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected rejected preflight to be %d, got %d", http.StatusForbidden, w.Code)
+		}
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Errorf("rejected preflight should not allow the origin")
+		}
+		if len(rejections) != 1 {
+			t.Errorf("expected one rejection callback, got %d", len(rejections))
+			continue
+		}
+		if rejections[0].Reason != tt.reason {
+			t.Errorf("expected rejection reason %q, got %q", tt.reason, rejections[0].Reason)
+		}
+		if rejections[0].Template != path {
+			t.Errorf("expected rejection template %q, got %q", path, rejections[0].Template)
+		}
+	}
+
+	if rejections[0].Headers[0] != "X-Other" {
+		t.Errorf("expected the offending header in the rejection, got %v", rejections[0].Headers)
+	}
+
+	// a good preflight is not reported
+	rejections = nil
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", "/users/1", nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || len(rejections) != 0 {
+		t.Errorf("good preflight should not be rejected, code: %d, rejections: %d", w.Code, len(rejections))
+	}
+}
+
+func TestCorsRejectionError(t *testing.T) {
+	err := &CorsRejection{Reason: CorsMethodNotAllowed, Method: "DELETE", Template: "/users/:id"}
+	if err.Error() != "cors preflight rejected: method not allowed (DELETE) for /users/:id" {
+		t.Errorf("unexpected rejection error string: %q", err.Error())
+	}
+}
+
+func TestCorsPerMethodPolicy(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin: []string{"test.com"},
+	})
+
+	path := "/users/:id"
+	f := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}
+	router.Get(path, f)
+	router.Delete(path, f)
+	router.SetCors(path, &CorsAccessControl{
+		AllowMethods: []string{"GET"},
+	})
+	router.SetMethodCors("GET", path, &CorsAccessControl{
+		AllowCredentials: true,
+		AllowHeaders:     []string{"X-Header"},
+	})
+
+	// GET is allowed credentials and headers
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", "/users/1", nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	r.Header.Add("Access-Control-Request-Headers", "X-Header")
+	router.ServeHTTP(w, r)
+	if w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Access-Control-Allow-Headers") != "X-Header" {
+		t.Errorf("GET preflight should use the GET policy, got %v", w.Header())
+	}
+
+	// DELETE is not
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest("OPTIONS", "/users/1", nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "DELETE")
+	router.ServeHTTP(w, r)
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("DELETE preflight should have been rejected, got %v", w.Header())
+	}
+
+	// actual requests use the policy of their own method
+	for method, creds := range map[string]string{"GET": "true", "HEAD": "true", "DELETE": ""} {
+		w = httptest.NewRecorder()
+		r, _ = http.NewRequest(method, "/users/1", nil)
+		r.Header.Add("Origin", "test.com")
+		router.ServeHTTP(w, r)
+		if got := w.Header().Get("Access-Control-Allow-Credentials"); got != creds {
+			t.Errorf("%s: expected allow credentials %q, got %q", method, creds, got)
+		}
+	}
+}
+
+func TestCorsPrivateNetworkPreflight(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin: []string{"test.com"},
+	})
+
+	f := func(w http.ResponseWriter, r *http.Request) {}
+	router.Get("/public", f)
+	router.Get("/private", f)
+	router.SetCors("/private", &CorsAccessControl{
+		AllowPrivateNetwork: true,
+	})
+
+	for path, allowed := range map[string]bool{"/public": false, "/private": true} {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("OPTIONS", path, nil)
+		r.Header.Add("Origin", "test.com")
+		r.Header.Add("Access-Control-Request-Method", "GET")
+		r.Header.Add("Access-Control-Request-Private-Network", "true")
+		router.ServeHTTP(w, r)
+
+		got := w.Header().Get("Access-Control-Allow-Private-Network") == "true"
+		if got != allowed {
+			t.Errorf("%s: expected private network allowed %v, got %v", path, allowed, got)
+		}
+		if (w.Header().Get("Access-Control-Allow-Origin") != "") != allowed {
+			t.Errorf("%s: private network preflight should only allow the origin when allowed", path)
+		}
+		if allowed && !strings.Contains(strings.Join(w.Header()["Vary"], ","), "Access-Control-Request-Private-Network") {
+			t.Errorf("%s: expected Vary on the private network request header", path)
+		}
+	}
+}
+
+func TestCorsMergeModes(t *testing.T) {
+	global := &CorsAccessControl{
+		AllowOrigin:      []string{"*", "test.com"},
+		AllowCredentials: true,
+		ExposeHeaders:    []string{"X-Header"},
+		MaxAge:           3600 * time.Second,
+		AllowHeaders:     []string{"X-Header"},
+	}
+
+	result := global.Merge(&CorsAccessControl{
+		AllowOrigin:   []string{"admin.test.com"},
+		ExposeHeaders: []string{"X-Y-Header"},
+		AllowHeaders:  []string{"X-Z-Header"},
+		MergeModes: CorsMergeModes{
+			AllowOrigin:      CorsMergeReplace,
+			AllowCredentials: CorsMergeReplace,
+			ExposeHeaders:    CorsMergeInherit,
+			MaxAge:           CorsMergeDisable,
+		},
+	})
+	assert.Equal(t, []string{"admin.test.com"}, result.GetAllowOrigin())
+	assert.False(t, result.GetAllowCredentials())
+	assert.Equal(t, []string{"X-Header"}, result.GetExposeHeaders())
+	assert.Equal(t, time.Duration(0), result.GetMaxAge())
+	assert.Equal(t, []string{"X-Header", "X-Z-Header"}, result.GetAllowHeaders())
+
+	// the global policy is left alone
+	assert.Equal(t, []string{"*", "test.com"}, global.GetAllowOrigin())
+	assert.True(t, global.GetAllowCredentials())
+}
+
+func TestCorsResourceReplacesGlobal(t *testing.T) {
+	router := NewRouter()
+	router.SetGlobalCors(&CorsAccessControl{
+		AllowOrigin:      []string{"*"},
+		AllowCredentials: true,
+	})
+
+	f := func(w http.ResponseWriter, r *http.Request) {}
+	router.Get("/public", f)
+	router.Get("/admin", f)
+	router.Get("/internal", f)
+	router.SetCors("/admin", &CorsAccessControl{
+		AllowOrigin: []string{"admin.test.com"},
+		MergeModes: CorsMergeModes{
+			AllowOrigin:      CorsMergeReplace,
+			AllowCredentials: CorsMergeDisable,
+		},
+	})
+	// a method policy on top keeps the replaced origin list of the resource
+	router.SetMethodCors("GET", "/admin", &CorsAccessControl{
+		AllowHeaders: []string{"X-Header"},
+	})
+	router.SetCors("/internal", &CorsAccessControl{Disabled: true})
+
+	tests := []struct {
+		path, origin, allowOrigin, allowCredentials string
+	}{
+		{"/public", "test.com", "*", ""},
+		{"/admin", "test.com", "", ""},
+		{"/admin", "admin.test.com", "admin.test.com", ""},
+		{"/internal", "test.com", "", ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", tt.path, nil)
+		r.Header.Add("Origin", tt.origin)
+		router.ServeHTTP(w, r)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+			t.Errorf("%s from %s: expected allow origin %q, got %q", tt.path, tt.origin, tt.allowOrigin, got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.allowCredentials {
+			t.Errorf("%s from %s: expected allow credentials %q, got %q", tt.path, tt.origin, tt.allowCredentials, got)
+		}
+	}
+
+	var rejection *CorsRejection
+	router.OnCorsReject(func(r *http.Request, rej *CorsRejection) {
+		rejection = rej
+	})
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("OPTIONS", "/internal", nil)
+	r.Header.Add("Origin", "test.com")
+	r.Header.Add("Access-Control-Request-Method", "GET")
+	router.ServeHTTP(w, r)
+	if rejection == nil || rejection.Reason != CorsDisabled {
+		t.Errorf("expected preflight against a disabled resource to be rejected, got %v", rejection)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FSOptions - Options of the files served by Router.ServeFS
+type FSOptions struct {
+	// Browse - list the files of directories that have no index file
+	Browse bool
+	// Index - the files served for a directory, the first one it has being
+	// served, index.html when empty
+	Index []string
+	// Precompressed - serve the gzipped sibling of a file, such as app.js.gz for
+	// app.js, to clients accepting gzip when there is one
+	Precompressed bool
+	// SPA - serve the index file of the root directory for paths that are not
+	// found and do not name a file (the last segment has no extension), for
+	// single page applications routing on the client
+	SPA bool
+}
+
+// ServeFS - Serve the files of fsys, such as an embed.FS or an os.DirFS, under
+// prefix, answering GET and HEAD requests.  Files are served with
+// http.ServeContent, which answers conditional and Range requests, along with an
+// ETag and, when fsys knows it, the Last-Modified time.  Directories are
+// redirected to their path with a trailing slash, and served with their index
+// file or, when browsing, a listing of their files.
+func (r *Router) ServeFS(prefix string, fsys fs.FS, opts *FSOptions) {
+	if strings.Contains(prefix, "*") {
+		panic("vestigo: file server prefix " + prefix + " can not have a wildcard")
+	}
+	s := &fileServer{fsys: fsys}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if len(s.opts.Index) == 0 {
+		s.opts.Index = []string{"index.html"}
+	}
+
+	prefix = strings.TrimSuffix(prefix, "/")
+	h := mountHandler(strings.Count(prefix, "/"), s)
+	if prefix != "" {
+		r.Get(prefix, h)
+	}
+	r.Get(prefix+"/*", h)
+}
+
+// fileServer - http.Handler serving the files of a fs.FS, the path of the
+// requests it is given being the path of the file in the fs.FS
+type fileServer struct {
+	fsys fs.FS
+	opts FSOptions
+	// etags - the ETags worked out from the content of files, for file systems
+	// that do not have modification times, such as embed.FS
+	etags sync.Map
+}
+
+func (s *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+	info, err := fs.Stat(s.fsys, name)
+	if err != nil {
+		if s.opts.SPA && !strings.Contains(path.Base(name), ".") && s.serveIndex(w, r, ".") {
+			return
+		}
+		notFoundHandler(w, r)
+		return
+	}
+	if !info.IsDir() {
+		s.serveFile(w, r, name, info)
+		return
+	}
+
+	if !strings.HasSuffix(OriginalPath(r), "/") {
+		// relative links of the index file and the listing need the slash
+		u := url.URL{Path: OriginalPath(r) + "/", RawQuery: clientQuery(r.URL.RawQuery)}
+		http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+		return
+	}
+	if s.serveIndex(w, r, name) {
+		return
+	}
+	if s.opts.Browse {
+		s.serveDir(w, r, name)
+		return
+	}
+	notFoundHandler(w, r)
+}
+
+// serveIndex - serve the index file of a directory, reporting whether it has one
+func (s *fileServer) serveIndex(w http.ResponseWriter, r *http.Request, dir string) bool {
+	for _, index := range s.opts.Index {
+		name := path.Join(dir, index)
+		if info, err := fs.Stat(s.fsys, name); err == nil && !info.IsDir() {
+			s.serveFile(w, r, name, info)
+			return true
+		}
+	}
+	return false
+}
+
+// serveFile - serve a file, or its gzipped sibling
+func (s *fileServer) serveFile(w http.ResponseWriter, r *http.Request, name string, info fs.FileInfo) {
+	if s.opts.Precompressed {
+		w.Header().Add("Vary", "Accept-Encoding")
+		// the content type is that of the file, and can not be sniffed from
+		// the gzipped content
+		if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" && acceptsGzip(r) {
+			if gz, err := fs.Stat(s.fsys, name+".gz"); err == nil && !gz.IsDir() {
+				w.Header().Set("Content-Type", ctype)
+				w.Header().Set("Content-Encoding", "gzip")
+				name, info = name+".gz", gz
+			}
+		}
+	}
+
+	f, err := s.fsys.Open(name)
+	if err != nil {
+		notFoundHandler(w, r)
+		return
+	}
+	defer f.Close()
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(b)
+	}
+	if etag, err := s.etag(name, info, content); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	http.ServeContent(w, r, name, info.ModTime(), content)
+}
+
+// etag - the ETag of a file, made of its modification time and size, or of a
+// hash of its content when the file system does not know when it was modified
+func (s *fileServer) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if !info.ModTime().IsZero() {
+		return `"` + strconv.FormatInt(info.ModTime().UnixNano(), 36) + "-" + strconv.FormatInt(info.Size(), 36) + `"`, nil
+	}
+	if etag, ok := s.etags.Load(name); ok {
+		return etag.(string), nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	s.etags.Store(name, etag)
+	return etag, nil
+}
+
+// serveDir - serve a listing of the files of a directory
+func (s *fileServer) serveDir(w http.ResponseWriter, r *http.Request, name string) {
+	entries, err := fs.ReadDir(s.fsys, name)
+	if err != nil {
+		notFoundHandler(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintln(w, "<pre>")
+	for _, e := range entries {
+		n := e.Name()
+		if e.IsDir() {
+			n += "/"
+		}
+		u := url.URL{Path: n}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", u.String(), html.EscapeString(n))
+	}
+	fmt.Fprintln(w, "</pre>")
+}
+
+// acceptsGzip - whether the client accepts gzipped content
+func acceptsGzip(r *http.Request) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		params := strings.Split(v, ";")
+		if strings.TrimSpace(params[0]) != "gzip" {
+			continue
+		}
+		for _, p := range params[1:] {
+			if q := strings.TrimSpace(p); strings.HasPrefix(q, "q=") {
+				if f, err := strconv.ParseFloat(q[2:], 64); err == nil && f == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"index.html":         {Data: []byte("<h1>app</h1>")},
+	"app.js":             {Data: []byte("console.log('app')"), ModTime: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+	"app.js.gz":          {Data: []byte("gzipped")},
+	"docs/index.htm":     {Data: []byte("docs")},
+	"images/logo.svg":    {Data: []byte("<svg/>")},
+	"images/a b&c.png":   {Data: []byte("png")},
+	"images/icons/x.svg": {Data: []byte("<svg/>")},
+}
+
+func serveFS(r *Router, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouter_ServeFS(t *testing.T) {
+	r := NewRouter()
+	r.ServeFS("/static/", testFS, &FSOptions{Browse: true, Index: []string{"index.html", "index.htm"}})
+
+	w := serveFS(r, "GET", "/static/app.js", nil)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "console.log('app')", w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+	assert.Equal(t, "Thu, 02 Jan 2020 03:04:05 GMT", w.Header().Get("Last-Modified"))
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Empty(t, w.Header().Get("Content-Encoding"), "precompressed files are opt in")
+
+	w = serveFS(r, "GET", "/static/app.js", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = serveFS(r, "GET", "/static/app.js", http.Header{"Range": {"bytes=0-6"}})
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "console", w.Body.String())
+
+	// embedded files have no modification time, their ETag is a hash of them
+	w = serveFS(r, "GET", "/static/images/logo.svg", nil)
+	etag = w.Header().Get("ETag")
+	assert.Equal(t, 34, len(etag))
+	assert.Equal(t, http.StatusNotModified, serveFS(r, "GET", "/static/images/logo.svg", http.Header{"If-None-Match": {etag}}).Code)
+
+	w = serveFS(r, "HEAD", "/static/app.js", nil)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "", w.Body.String())
+
+	w = serveFS(r, "POST", "/static/app.js", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+
+	for path, location := range map[string]string{
+		"/static":              "/static/",
+		"/static/docs":         "/static/docs/",
+		"/static/docs?lang=en": "/static/docs/?lang=en",
+	} {
+		w = serveFS(r, "GET", path, nil)
+		assert.Equal(t, http.StatusMovedPermanently, w.Code, path)
+		assert.Equal(t, location, w.Header().Get("Location"), path)
+	}
+	assert.Equal(t, "<h1>app</h1>", serveFS(r, "GET", "/static/", nil).Body.String())
+	assert.Equal(t, "docs", serveFS(r, "GET", "/static/docs/", nil).Body.String())
+
+	w = serveFS(r, "GET", "/static/images/", nil)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "<pre>\n<a href=\"a%20b&c.png\">a b&amp;c.png</a>\n<a href=\"icons/\">icons/</a>\n<a href=\"logo.svg\">logo.svg</a>\n</pre>\n", w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serveFS(r, "GET", "/static/nope", nil).Code)
+	assert.Equal(t, http.StatusNotFound, serveFS(r, "GET", "/static/../fs.go", nil).Code)
+}
+
+func TestRouter_ServeFSOptions(t *testing.T) {
+	r := NewRouter()
+	r.ServeFS("/", testFS, &FSOptions{Precompressed: true, SPA: true})
+
+	w := serveFS(r, "GET", "/app.js", http.Header{"Accept-Encoding": {"br, gzip;q=0.8"}})
+	assert.Equal(t, "gzipped", w.Body.String())
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+	for _, encoding := range []string{"", "br", "gzip;q=0"} {
+		w = serveFS(r, "GET", "/app.js", http.Header{"Accept-Encoding": {encoding}})
+		assert.Equal(t, "console.log('app')", w.Body.String(), encoding)
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"), encoding)
+	}
+
+	// paths that are not files are the application's, and get its index
+	for _, path := range []string{"/users/1", "/images/nope"} {
+		w = serveFS(r, "GET", path, nil)
+		assert.Equal(t, 200, w.Code, path)
+		assert.Equal(t, "<h1>app</h1>", w.Body.String(), path)
+	}
+	assert.Equal(t, http.StatusNotFound, serveFS(r, "GET", "/images/nope.png", nil).Code)
+	assert.Equal(t, http.StatusNotFound, serveFS(r, "GET", "/images/", nil).Code, "browsing is opt in")
+}
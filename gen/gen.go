@@ -0,0 +1,393 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+// Package gen generates Go source for matchers that replace the walk of the
+// vestigo router tree for a fixed route table.  The generated matcher is a
+// switch based state machine following the same steps as the router's own
+// lookup, so it matches paths exactly as the tree does, and is plugged into a
+// router with Router.SetMatcher.  An equivalence test comparing the generated
+// matcher with the tree is generated alongside it.
+package gen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/husobee/vestigo"
+)
+
+// Route - a route declaration, a method and a path template
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Config - settings of the generated source
+type Config struct {
+	// Package - the package the generated source belongs to
+	Package string
+	// Type - the name of the generated matcher type, Matcher when empty
+	Type string
+}
+
+// ParseRoutes - Read route declarations, one "METHOD /path" per line.  Blank
+// lines and lines starting with # are ignored.
+func ParseRoutes(r io.Reader) ([]Route, error) {
+	var routes []Route
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("gen: line %d: expected a method and a path, got %q", line, text)
+		}
+		if !strings.HasPrefix(fields[1], "/") {
+			return nil, fmt.Errorf("gen: line %d: path %q does not start with /", line, fields[1])
+		}
+		routes = append(routes, Route{Method: strings.ToUpper(fields[0]), Path: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+// Generate - Generate the source of the matcher for the routes, and the source of
+// its equivalence test, both formatted
+func Generate(routes []Route, c Config) (matcher, test []byte, err error) {
+	if c.Package == "" {
+		return nil, nil, fmt.Errorf("gen: no package given")
+	}
+	if c.Type == "" {
+		c.Type = "Matcher"
+	}
+	nodes, err := describe(routes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := generator{Config: c, prefix: unexported(c.Type)}
+	g.matcher(nodes)
+	if matcher, err = format.Source(g.Bytes()); err != nil {
+		return nil, nil, fmt.Errorf("gen: formatting matcher: %v", err)
+	}
+	g.Reset()
+	g.test(routes)
+	if test, err = format.Source(g.Bytes()); err != nil {
+		return nil, nil, fmt.Errorf("gen: formatting test: %v", err)
+	}
+	return matcher, test, nil
+}
+
+// describe - build the router tree of the routes, and describe its nodes
+func describe(routes []Route) (nodes []vestigo.MatcherNode, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("gen: %v", r)
+		}
+	}()
+	r := vestigo.NewRouter()
+	for _, route := range routes {
+		r.Add(route.Method, route.Path, func(w http.ResponseWriter, r *http.Request) {})
+	}
+	return r.MatcherNodes(), nil
+}
+
+// generator - buffer the source is generated into
+type generator struct {
+	bytes.Buffer
+	Config
+	// prefix - prefix of the unexported identifiers of the generated source
+	prefix string
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(g, format, args...)
+	g.WriteByte('\n')
+}
+
+// matcher - generate the matcher, a state machine over the nodes of the tree
+// taking the same steps as the lookup of the router
+func (g *generator) matcher(nodes []vestigo.MatcherNode) {
+	g.p("// Code generated by vestigo-gen. DO NOT EDIT.")
+	g.p("")
+	g.p("package %s", g.Package)
+	g.p("")
+	g.p(`import "strings"`)
+	g.p("")
+	g.p("// %s - matcher of the route table, implementing vestigo.Matcher", g.Type)
+	g.p("type %s struct{}", g.Type)
+	g.p("")
+	g.table("Nodes", "[]string", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprintf("%q", n.Key) })
+	g.table("Prefix", "[...]string", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprintf("%q", n.Prefix) })
+	g.table("Parent", "[...]int", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.Parent) })
+	g.table("Param", "[...]int", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.Param) })
+	g.table("Any", "[...]int", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.Any) })
+	g.table("ParamLabel", "[...]bool", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.ParamLabel) })
+	g.table("AnyLabel", "[...]bool", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.AnyLabel) })
+	g.table("Leaf", "[...]bool", nodes, func(n vestigo.MatcherNode) string { return fmt.Sprint(n.Leaf) })
+
+	g.p("// Nodes - the keys of the nodes of the router tree the matcher was generated for")
+	g.p("func (%s) Nodes() []string {", g.Type)
+	g.p("return %sNodes", g.prefix)
+	g.p("}")
+	g.p("")
+	g.p("// Match - match the path, returning the index of the node it matches")
+	g.p("func (%s) Match(path string, values []string) (int, []string) {", g.Type)
+	g.p("var (")
+	g.p("n = 0")
+	g.p("search = path")
+	g.p("tmpsearch string")
+	g.p(")")
+	g.p("for {")
+	g.p(`if search == "" {`)
+	g.p("return n, values")
+	g.p("}")
+	g.p("")
+	g.p("// the prefix of the node")
+	g.p("switch n {")
+	for i, n := range nodes {
+		if n.Prefix == "" || n.Prefix[0] == ':' {
+			continue
+		}
+		g.p("case %d:", i)
+		g.p("if strings.HasPrefix(search, %q) {", n.Prefix)
+		g.p("search = search[%d:]", len(n.Prefix))
+		if n.Parent >= 0 && !n.Allowed {
+			// a node without routes of its own falls back on the
+			// nearest match-any node of its ancestors, if it has one
+			for p := n.Parent; p >= 0; p = nodes[p].Parent {
+				if nodes[p].AnyLabel {
+					g.p(`if search == "" {`)
+					g.p("search = %q", nodes[p].Prefix+n.Prefix)
+					g.p("n = %d", p)
+					g.p("goto matchAny")
+					g.p("}")
+					break
+				}
+			}
+		}
+		g.p("}")
+	}
+	g.p("}")
+	g.p("")
+	g.p(`if search == "" {`)
+	g.p("if %sAny[n] < 0 {", g.prefix)
+	g.p("continue")
+	g.p("}")
+	g.p("goto matchAny")
+	g.p("}")
+	g.p("")
+	g.p("// static children")
+	g.p("switch n {")
+	for i, n := range nodes {
+		if len(n.Static) == 0 {
+			continue
+		}
+		g.p("case %d:", i)
+		g.p("switch search[0] {")
+		for _, c := range n.Static {
+			g.p("case %s:", byteLiteral(nodes[c].Prefix[0]))
+			g.p("if strings.HasPrefix(search, %q) {", nodes[c].Prefix)
+			g.p("n = %d", c)
+			g.p("continue")
+			g.p("}")
+		}
+		g.p("}")
+	}
+	g.p("}")
+	g.p("")
+	g.p("matchParam:")
+	g.p("if c := %sParam[n]; c >= 0 {", g.prefix)
+	g.p("n = c")
+	g.p("i := strings.IndexByte(search, '/')")
+	g.p("if i < 0 {")
+	g.p("i = len(search)")
+	g.p("}")
+	g.p("values = append(values, search[:i])")
+	g.p("search = search[i:]")
+	g.p(`if %sLeaf[n] && search != "" {`, g.prefix)
+	g.p("return -1, values")
+	g.p("}")
+	g.p("continue")
+	g.p("}")
+	g.p("")
+	g.p("matchAny:")
+	g.p("if c := %sAny[n]; c >= 0 {", g.prefix)
+	g.p("n = c")
+	g.p("values = append(values, search)")
+	g.p(`search = ""`)
+	g.p("continue")
+	g.p("}")
+	g.p("")
+	g.p("// last ditch effort to match on a param or wildcard of an ancestor")
+	g.p("tmpsearch = search")
+	g.p(`for %sParent[n] >= 0 && %sPrefix[n] != ":" {`, g.prefix, g.prefix)
+	g.p("tmpsearch = %sPrefix[n] + tmpsearch", g.prefix)
+	g.p("n = %sParent[n]", g.prefix)
+	g.p(`if strings.HasSuffix(%sPrefix[n], "/") {`, g.prefix)
+	g.p("if %sParamLabel[n] {", g.prefix)
+	g.p("search = tmpsearch")
+	g.p("goto matchParam")
+	g.p("}")
+	g.p("if %sAnyLabel[n] {", g.prefix)
+	g.p("search = tmpsearch")
+	g.p("goto matchAny")
+	g.p("}")
+	g.p("}")
+	g.p("}")
+	g.p("return -1, values")
+	g.p("}")
+	g.p("}")
+}
+
+// table - generate a table with a value for every node
+func (g *generator) table(name, typ string, nodes []vestigo.MatcherNode, value func(vestigo.MatcherNode) string) {
+	g.p("var %s%s = %s{", g.prefix, name, typ)
+	for _, n := range nodes {
+		g.p("%s,", value(n))
+	}
+	g.p("}")
+	g.p("")
+}
+
+// test - generate the test checking the matcher matches the paths exercising
+// the routes exactly as the router tree does
+func (g *generator) test(routes []Route) {
+	g.p("// Code generated by vestigo-gen. DO NOT EDIT.")
+	g.p("")
+	g.p("package %s", g.Package)
+	g.p("")
+	g.p("import (")
+	g.p(`"net/http"`)
+	g.p(`"net/http/httptest"`)
+	g.p(`"reflect"`)
+	g.p(`"testing"`)
+	g.p("")
+	g.p(`"github.com/husobee/vestigo"`)
+	g.p(")")
+	g.p("")
+	g.p("var %sTestRoutes = [][2]string{", g.prefix)
+	for _, route := range routes {
+		g.p("{%q, %q},", route.Method, route.Path)
+	}
+	g.p("}")
+	g.p("")
+	g.p("var %sTestPaths = []string{", g.prefix)
+	for _, path := range samplePaths(routes) {
+		g.p("%q,", path)
+	}
+	g.p("}")
+	g.p("")
+	g.p("func Test%sEquivalence(t *testing.T) {", g.Type)
+	g.p("ok := func(w http.ResponseWriter, r *http.Request) {")
+	g.p("w.WriteHeader(http.StatusOK)")
+	g.p("}")
+	g.p("tree, generated := vestigo.NewRouter(), vestigo.NewRouter()")
+	g.p("for _, route := range %sTestRoutes {", g.prefix)
+	g.p("tree.Add(route[0], route[1], ok)")
+	g.p("generated.Add(route[0], route[1], ok)")
+	g.p("}")
+	g.p("if err := generated.SetMatcher(%s{}); err != nil {", g.Type)
+	g.p("t.Fatal(err)")
+	g.p("}")
+	g.p("")
+	g.p("for _, path := range %sTestPaths {", g.prefix)
+	g.p(`for _, method := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"} {`)
+	g.p("h1, params1, template1, allowed1 := tree.Lookup(method, path)")
+	g.p("h2, params2, template2, allowed2 := generated.Lookup(method, path)")
+	g.p("if (h1 == nil) != (h2 == nil) || !reflect.DeepEqual(params1, params2) || template1 != template2 || !reflect.DeepEqual(allowed1, allowed2) {")
+	g.p(`t.Errorf("%%s %%s: tree matched %%q %%v %%v, generated matcher %%q %%v %%v", method, path, template1, params1, allowed1, template2, params2, allowed2)`)
+	g.p("}")
+	g.p("")
+	g.p("w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()")
+	g.p("tree.ServeHTTP(w1, httptest.NewRequest(method, path, nil))")
+	g.p("generated.ServeHTTP(w2, httptest.NewRequest(method, path, nil))")
+	g.p(`if w1.Code != w2.Code || w1.Header().Get("Allow") != w2.Header().Get("Allow") {`)
+	g.p(`t.Errorf("%%s %%s: tree answered %%d, generated matcher %%d", method, path, w1.Code, w2.Code)`)
+	g.p("}")
+	g.p("}")
+	g.p("}")
+	g.p("}")
+}
+
+// samplePaths - paths exercising the routes, with their params filled in a few
+// ways, their parts, and paths going on past them
+func samplePaths(routes []Route) []string {
+	seen := map[string]bool{"/": true}
+	add := func(path string) {
+		if strings.HasPrefix(path, "/") {
+			seen[path] = true
+		}
+	}
+	for _, route := range routes {
+		for _, filled := range []string{
+			route.Path,
+			fill(route.Path, "x", "a/b"),
+			fill(route.Path, "", ""),
+		} {
+			add(filled)
+			add(filled + "/")
+			add(filled + "/extra")
+			for i := 1; i < len(filled); i++ {
+				if filled[i] == '/' {
+					add(filled[:i])
+					add(filled[:i+1])
+				}
+			}
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// fill - fill the params and the wildcard of a path template in
+func fill(template, param, wildcard string) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case ':':
+			for i+1 < len(template) && template[i+1] != '/' {
+				i++
+			}
+			b.WriteString(param)
+		case '*':
+			b.WriteString(wildcard)
+			return b.String()
+		default:
+			b.WriteByte(template[i])
+		}
+	}
+	return b.String()
+}
+
+// byteLiteral - a Go literal of the byte
+func byteLiteral(b byte) string {
+	if b < 0x80 && unicode.IsPrint(rune(b)) {
+		return fmt.Sprintf("%q", rune(b))
+	}
+	return fmt.Sprintf("0x%02x", b)
+}
+
+// unexported - the name with its first letter lower cased
+func unexported(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
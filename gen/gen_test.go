@@ -0,0 +1,67 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package gen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes(strings.NewReader(`
+# users
+get /users/:id
+POST   /users
+`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Route{{"GET", "/users/:id"}, {"POST", "/users"}}, routes)
+	}
+
+	for _, doc := range []string{"GET", "GET users", "GET /a /b"} {
+		_, err := ParseRoutes(strings.NewReader(doc))
+		assert.Error(t, err, doc)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, _, err := Generate([]Route{{"GET", "/"}}, Config{})
+	assert.Error(t, err)
+	_, _, err = Generate([]Route{{"BREW", "/coffee"}}, Config{Package: "coffee"})
+	assert.Error(t, err)
+}
+
+// TestGeneratedUpToDate - the matchers checked in under internal, whose
+// equivalence tests run with the rest of the tests, are what the generator
+// generates now
+func TestGeneratedUpToDate(t *testing.T) {
+	for _, pkg := range []string{"githubapi", "edgecases"} {
+		dir := filepath.Join("internal", pkg)
+		f, err := os.Open(filepath.Join(dir, "routes.txt"))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		routes, err := ParseRoutes(f)
+		f.Close()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		matcher, test, err := Generate(routes, Config{Package: pkg})
+		if !assert.NoError(t, err) {
+			continue
+		}
+		for file, generated := range map[string][]byte{"matcher_gen.go": matcher, "matcher_gen_test.go": test} {
+			checkedIn, err := ioutil.ReadFile(filepath.Join(dir, file))
+			if assert.NoError(t, err) && !bytes.Equal(checkedIn, generated) {
+				t.Errorf("%s/%s is out of date, run go generate", pkg, file)
+			}
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+// Package edgecases holds the matcher generated for a route table exercising
+// the corners of the router tree, and its equivalence test.
+package edgecases
+
+//go:generate go run ../../../cmd/vestigo-gen -routes routes.txt -o matcher_gen.go
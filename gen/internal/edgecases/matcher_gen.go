@@ -0,0 +1,670 @@
+// Code generated by vestigo-gen. DO NOT EDIT.
+
+package edgecases
+
+import "strings"
+
+// Matcher - matcher of the route table, implementing vestigo.Matcher
+type Matcher struct{}
+
+var matcherNodes = []string{
+	"/",
+	"/*",
+	"/health",
+	"/_/",
+	"/v",
+	"/us",
+	"/static/",
+	"/a/",
+	"/b/",
+	"/c/",
+	"/_/accounts/foo",
+	"/_/:",
+	"/v:",
+	"/user",
+	"/static/*",
+	"/a/:",
+	"/a/*",
+	"/b/:",
+	"/b/*",
+	"/c/*",
+	"/c/:",
+	"/_/:/bar",
+	"/v:/hi",
+	"/users/",
+	"/userinfo",
+	"/b/:/c",
+	"/c/:/x/",
+	"/users/:",
+	"/users/new",
+	"/b/:/c/",
+	"/c/:/x/*",
+	"/users/:/files/",
+	"/b/:/c/:",
+	"/users/:/files/*",
+}
+
+var matcherPrefix = [...]string{
+	"/",
+	"*",
+	"health",
+	"_/",
+	"v",
+	"us",
+	"static/",
+	"a/",
+	"b/",
+	"c/",
+	"accounts/foo",
+	":",
+	":",
+	"er",
+	"*",
+	":",
+	"*",
+	":",
+	"*",
+	"*",
+	":",
+	"/bar",
+	"/hi",
+	"s/",
+	"info",
+	"/c",
+	"/x/",
+	":",
+	"new",
+	"/",
+	"*",
+	"/files/",
+	":",
+	"*",
+}
+
+var matcherParent = [...]int{
+	-1,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	3,
+	3,
+	4,
+	5,
+	6,
+	7,
+	7,
+	8,
+	8,
+	9,
+	9,
+	11,
+	12,
+	13,
+	13,
+	17,
+	20,
+	23,
+	23,
+	25,
+	26,
+	27,
+	29,
+	31,
+}
+
+var matcherParam = [...]int{
+	-1,
+	-1,
+	-1,
+	11,
+	12,
+	-1,
+	-1,
+	15,
+	17,
+	20,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	27,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	32,
+	-1,
+	-1,
+	-1,
+	-1,
+}
+
+var matcherAny = [...]int{
+	1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	14,
+	16,
+	18,
+	19,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	30,
+	-1,
+	-1,
+	-1,
+	-1,
+	33,
+	-1,
+	-1,
+}
+
+var matcherParamLabel = [...]bool{
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+}
+
+var matcherAnyLabel = [...]bool{
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+}
+
+var matcherLeaf = [...]bool{
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	false,
+	true,
+	true,
+}
+
+// Nodes - the keys of the nodes of the router tree the matcher was generated for
+func (Matcher) Nodes() []string {
+	return matcherNodes
+}
+
+// Match - match the path, returning the index of the node it matches
+func (Matcher) Match(path string, values []string) (int, []string) {
+	var (
+		n         = 0
+		search    = path
+		tmpsearch string
+	)
+	for {
+		if search == "" {
+			return n, values
+		}
+
+		// the prefix of the node
+		switch n {
+		case 0:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 1:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 2:
+			if strings.HasPrefix(search, "health") {
+				search = search[6:]
+			}
+		case 3:
+			if strings.HasPrefix(search, "_/") {
+				search = search[2:]
+				if search == "" {
+					search = "/_/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 4:
+			if strings.HasPrefix(search, "v") {
+				search = search[1:]
+				if search == "" {
+					search = "/v"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 5:
+			if strings.HasPrefix(search, "us") {
+				search = search[2:]
+			}
+		case 6:
+			if strings.HasPrefix(search, "static/") {
+				search = search[7:]
+				if search == "" {
+					search = "/static/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 7:
+			if strings.HasPrefix(search, "a/") {
+				search = search[2:]
+				if search == "" {
+					search = "/a/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 8:
+			if strings.HasPrefix(search, "b/") {
+				search = search[2:]
+				if search == "" {
+					search = "/b/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 9:
+			if strings.HasPrefix(search, "c/") {
+				search = search[2:]
+				if search == "" {
+					search = "/c/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 10:
+			if strings.HasPrefix(search, "accounts/foo") {
+				search = search[12:]
+			}
+		case 13:
+			if strings.HasPrefix(search, "er") {
+				search = search[2:]
+				if search == "" {
+					search = "/er"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 14:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 16:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 18:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 19:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 21:
+			if strings.HasPrefix(search, "/bar") {
+				search = search[4:]
+			}
+		case 22:
+			if strings.HasPrefix(search, "/hi") {
+				search = search[3:]
+			}
+		case 23:
+			if strings.HasPrefix(search, "s/") {
+				search = search[2:]
+				if search == "" {
+					search = "/s/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 24:
+			if strings.HasPrefix(search, "info") {
+				search = search[4:]
+			}
+		case 25:
+			if strings.HasPrefix(search, "/c") {
+				search = search[2:]
+			}
+		case 26:
+			if strings.HasPrefix(search, "/x/") {
+				search = search[3:]
+				if search == "" {
+					search = "c//x/"
+					n = 9
+					goto matchAny
+				}
+			}
+		case 28:
+			if strings.HasPrefix(search, "new") {
+				search = search[3:]
+			}
+		case 29:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+				if search == "" {
+					search = "b//"
+					n = 8
+					goto matchAny
+				}
+			}
+		case 30:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 31:
+			if strings.HasPrefix(search, "/files/") {
+				search = search[7:]
+				if search == "" {
+					search = "//files/"
+					n = 0
+					goto matchAny
+				}
+			}
+		case 33:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		}
+
+		if search == "" {
+			if matcherAny[n] < 0 {
+				continue
+			}
+			goto matchAny
+		}
+
+		// static children
+		switch n {
+		case 0:
+			switch search[0] {
+			case '_':
+				if strings.HasPrefix(search, "_/") {
+					n = 3
+					continue
+				}
+			case 'a':
+				if strings.HasPrefix(search, "a/") {
+					n = 7
+					continue
+				}
+			case 'b':
+				if strings.HasPrefix(search, "b/") {
+					n = 8
+					continue
+				}
+			case 'c':
+				if strings.HasPrefix(search, "c/") {
+					n = 9
+					continue
+				}
+			case 'h':
+				if strings.HasPrefix(search, "health") {
+					n = 2
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "static/") {
+					n = 6
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "us") {
+					n = 5
+					continue
+				}
+			case 'v':
+				if strings.HasPrefix(search, "v") {
+					n = 4
+					continue
+				}
+			}
+		case 3:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "accounts/foo") {
+					n = 10
+					continue
+				}
+			}
+		case 5:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "er") {
+					n = 13
+					continue
+				}
+			}
+		case 11:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/bar") {
+					n = 21
+					continue
+				}
+			}
+		case 12:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/hi") {
+					n = 22
+					continue
+				}
+			}
+		case 13:
+			switch search[0] {
+			case 'i':
+				if strings.HasPrefix(search, "info") {
+					n = 24
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "s/") {
+					n = 23
+					continue
+				}
+			}
+		case 17:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/c") {
+					n = 25
+					continue
+				}
+			}
+		case 20:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/x/") {
+					n = 26
+					continue
+				}
+			}
+		case 23:
+			switch search[0] {
+			case 'n':
+				if strings.HasPrefix(search, "new") {
+					n = 28
+					continue
+				}
+			}
+		case 25:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 29
+					continue
+				}
+			}
+		case 27:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/files/") {
+					n = 31
+					continue
+				}
+			}
+		}
+
+	matchParam:
+		if c := matcherParam[n]; c >= 0 {
+			n = c
+			i := strings.IndexByte(search, '/')
+			if i < 0 {
+				i = len(search)
+			}
+			values = append(values, search[:i])
+			search = search[i:]
+			if matcherLeaf[n] && search != "" {
+				return -1, values
+			}
+			continue
+		}
+
+	matchAny:
+		if c := matcherAny[n]; c >= 0 {
+			n = c
+			values = append(values, search)
+			search = ""
+			continue
+		}
+
+		// last ditch effort to match on a param or wildcard of an ancestor
+		tmpsearch = search
+		for matcherParent[n] >= 0 && matcherPrefix[n] != ":" {
+			tmpsearch = matcherPrefix[n] + tmpsearch
+			n = matcherParent[n]
+			if strings.HasSuffix(matcherPrefix[n], "/") {
+				if matcherParamLabel[n] {
+					search = tmpsearch
+					goto matchParam
+				}
+				if matcherAnyLabel[n] {
+					search = tmpsearch
+					goto matchAny
+				}
+			}
+		}
+		return -1, values
+	}
+}
@@ -0,0 +1,250 @@
+// Code generated by vestigo-gen. DO NOT EDIT.
+
+package edgecases
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/husobee/vestigo"
+)
+
+var matcherTestRoutes = [][2]string{
+	{"GET", "/"},
+	{"GET", "/*"},
+	{"GET", "/health"},
+	{"GET", "/_/accounts/foo"},
+	{"GET", "/_/:project/bar"},
+	{"GET", "/v:version/hi"},
+	{"GET", "/users/:id"},
+	{"PUT", "/users/:uid"},
+	{"GET", "/users/:id/files/*"},
+	{"GET", "/users/new"},
+	{"GET", "/userinfo"},
+	{"GET", "/us"},
+	{"GET", "/static/*"},
+	{"GET", "/a/:id"},
+	{"GET", "/a/*"},
+	{"GET", "/b/:id/c"},
+	{"GET", "/b/*"},
+	{"POST", "/b/:id/c/:d"},
+	{"DELETE", "/c/*"},
+	{"GET", "/c/:id/x/*"},
+}
+
+var matcherTestPaths = []string{
+	"/",
+	"/*",
+	"/*/",
+	"/*/extra",
+	"//",
+	"//extra",
+	"/_",
+	"/_/",
+	"/_//",
+	"/_//bar",
+	"/_//bar/",
+	"/_//bar/extra",
+	"/_/:project",
+	"/_/:project/",
+	"/_/:project/bar",
+	"/_/:project/bar/",
+	"/_/:project/bar/extra",
+	"/_/accounts",
+	"/_/accounts/",
+	"/_/accounts/foo",
+	"/_/accounts/foo/",
+	"/_/accounts/foo/extra",
+	"/_/x",
+	"/_/x/",
+	"/_/x/bar",
+	"/_/x/bar/",
+	"/_/x/bar/extra",
+	"/a",
+	"/a/",
+	"/a/*",
+	"/a/*/",
+	"/a/*/extra",
+	"/a//",
+	"/a//extra",
+	"/a/:id",
+	"/a/:id/",
+	"/a/:id/extra",
+	"/a/a",
+	"/a/a/",
+	"/a/a/b",
+	"/a/a/b/",
+	"/a/a/b/extra",
+	"/a/b",
+	"/a/b/",
+	"/a/b/extra",
+	"/a/x",
+	"/a/x/",
+	"/a/x/extra",
+	"/b",
+	"/b/",
+	"/b/*",
+	"/b/*/",
+	"/b/*/extra",
+	"/b//",
+	"/b//c",
+	"/b//c/",
+	"/b//c//",
+	"/b//c//extra",
+	"/b//c/extra",
+	"/b//extra",
+	"/b/:id",
+	"/b/:id/",
+	"/b/:id/c",
+	"/b/:id/c/",
+	"/b/:id/c/:d",
+	"/b/:id/c/:d/",
+	"/b/:id/c/:d/extra",
+	"/b/:id/c/extra",
+	"/b/a",
+	"/b/a/",
+	"/b/a/b",
+	"/b/a/b/",
+	"/b/a/b/extra",
+	"/b/x",
+	"/b/x/",
+	"/b/x/c",
+	"/b/x/c/",
+	"/b/x/c/extra",
+	"/b/x/c/x",
+	"/b/x/c/x/",
+	"/b/x/c/x/extra",
+	"/c",
+	"/c/",
+	"/c/*",
+	"/c/*/",
+	"/c/*/extra",
+	"/c//",
+	"/c//extra",
+	"/c//x",
+	"/c//x/",
+	"/c//x//",
+	"/c//x//extra",
+	"/c/:id",
+	"/c/:id/",
+	"/c/:id/x",
+	"/c/:id/x/",
+	"/c/:id/x/*",
+	"/c/:id/x/*/",
+	"/c/:id/x/*/extra",
+	"/c/a",
+	"/c/a/",
+	"/c/a/b",
+	"/c/a/b/",
+	"/c/a/b/extra",
+	"/c/x",
+	"/c/x/",
+	"/c/x/x",
+	"/c/x/x/",
+	"/c/x/x/a",
+	"/c/x/x/a/",
+	"/c/x/x/a/b",
+	"/c/x/x/a/b/",
+	"/c/x/x/a/b/extra",
+	"/health",
+	"/health/",
+	"/health/extra",
+	"/static",
+	"/static/",
+	"/static/*",
+	"/static/*/",
+	"/static/*/extra",
+	"/static//",
+	"/static//extra",
+	"/static/a",
+	"/static/a/",
+	"/static/a/b",
+	"/static/a/b/",
+	"/static/a/b/extra",
+	"/us",
+	"/us/",
+	"/us/extra",
+	"/userinfo",
+	"/userinfo/",
+	"/userinfo/extra",
+	"/users",
+	"/users/",
+	"/users//",
+	"/users//extra",
+	"/users//files",
+	"/users//files/",
+	"/users//files//",
+	"/users//files//extra",
+	"/users/:id",
+	"/users/:id/",
+	"/users/:id/extra",
+	"/users/:id/files",
+	"/users/:id/files/",
+	"/users/:id/files/*",
+	"/users/:id/files/*/",
+	"/users/:id/files/*/extra",
+	"/users/:uid",
+	"/users/:uid/",
+	"/users/:uid/extra",
+	"/users/new",
+	"/users/new/",
+	"/users/new/extra",
+	"/users/x",
+	"/users/x/",
+	"/users/x/extra",
+	"/users/x/files",
+	"/users/x/files/",
+	"/users/x/files/a",
+	"/users/x/files/a/",
+	"/users/x/files/a/b",
+	"/users/x/files/a/b/",
+	"/users/x/files/a/b/extra",
+	"/v",
+	"/v/",
+	"/v/hi",
+	"/v/hi/",
+	"/v/hi/extra",
+	"/v:version",
+	"/v:version/",
+	"/v:version/hi",
+	"/v:version/hi/",
+	"/v:version/hi/extra",
+	"/vx",
+	"/vx/",
+	"/vx/hi",
+	"/vx/hi/",
+	"/vx/hi/extra",
+}
+
+func TestMatcherEquivalence(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	tree, generated := vestigo.NewRouter(), vestigo.NewRouter()
+	for _, route := range matcherTestRoutes {
+		tree.Add(route[0], route[1], ok)
+		generated.Add(route[0], route[1], ok)
+	}
+	if err := generated.SetMatcher(Matcher{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range matcherTestPaths {
+		for _, method := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"} {
+			h1, params1, template1, allowed1 := tree.Lookup(method, path)
+			h2, params2, template2, allowed2 := generated.Lookup(method, path)
+			if (h1 == nil) != (h2 == nil) || !reflect.DeepEqual(params1, params2) || template1 != template2 || !reflect.DeepEqual(allowed1, allowed2) {
+				t.Errorf("%s %s: tree matched %q %v %v, generated matcher %q %v %v", method, path, template1, params1, allowed1, template2, params2, allowed2)
+			}
+
+			w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+			tree.ServeHTTP(w1, httptest.NewRequest(method, path, nil))
+			generated.ServeHTTP(w2, httptest.NewRequest(method, path, nil))
+			if w1.Code != w2.Code || w1.Header().Get("Allow") != w2.Header().Get("Allow") {
+				t.Errorf("%s %s: tree answered %d, generated matcher %d", method, path, w1.Code, w2.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+// Package githubapi holds the matcher generated for the GitHub API route table
+// of the vestigo benchmarks, and its equivalence test.
+package githubapi
+
+//go:generate go run ../../../cmd/vestigo-gen -routes routes.txt -o matcher_gen.go
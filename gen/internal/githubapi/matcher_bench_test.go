@@ -0,0 +1,43 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package githubapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/husobee/vestigo"
+)
+
+func benchmarkLookup(b *testing.B, r *vestigo.Router) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range matcherTestPaths {
+			r.Lookup("GET", path)
+		}
+	}
+}
+
+func benchmarkRouter(matcher bool) *vestigo.Router {
+	r := vestigo.NewRouter()
+	for _, route := range matcherTestRoutes {
+		r.Add(route[0], route[1], func(w http.ResponseWriter, r *http.Request) {})
+	}
+	if matcher {
+		if err := r.SetMatcher(Matcher{}); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func BenchmarkTreeLookup(b *testing.B) {
+	benchmarkLookup(b, benchmarkRouter(false))
+}
+
+func BenchmarkMatcherLookup(b *testing.B) {
+	benchmarkLookup(b, benchmarkRouter(true))
+}
@@ -0,0 +1,4422 @@
+// Code generated by vestigo-gen. DO NOT EDIT.
+
+package githubapi
+
+import "strings"
+
+// Matcher - matcher of the route table, implementing vestigo.Matcher
+type Matcher struct{}
+
+var matcherNodes = []string{
+	"/",
+	"/a",
+	"/e",
+	"/r",
+	"/n",
+	"/orgs/",
+	"/user",
+	"/feeds",
+	"/gi",
+	"/issues",
+	"/m",
+	"/teams/",
+	"/search/",
+	"/legacy/",
+	"/authorizations",
+	"/applications/",
+	"/events",
+	"/emojis",
+	"/repos",
+	"/rate_limit",
+	"/networks/",
+	"/notifications",
+	"/orgs/:",
+	"/users",
+	"/user/",
+	"/gists",
+	"/gitignore/templates",
+	"/markdown",
+	"/meta",
+	"/teams/:",
+	"/search/repositories",
+	"/search/code",
+	"/search/issues",
+	"/search/users",
+	"/legacy/issues/search/",
+	"/legacy/repos/search/",
+	"/legacy/user/",
+	"/authorizations/",
+	"/applications/:",
+	"/repos/",
+	"/repositories",
+	"/networks/:",
+	"/notifications/threads/",
+	"/orgs/:/",
+	"/users/",
+	"/user/s",
+	"/user/issues",
+	"/user/orgs",
+	"/user/teams",
+	"/user/repos",
+	"/user/emails",
+	"/user/follow",
+	"/user/keys",
+	"/gists/",
+	"/gitignore/templates/",
+	"/markdown/raw",
+	"/teams/:/",
+	"/legacy/issues/search/:",
+	"/legacy/repos/search/:",
+	"/legacy/user/search/",
+	"/legacy/user/email/",
+	"/authorizations/:",
+	"/authorizations/clients/",
+	"/applications/:/tokens",
+	"/repos/:",
+	"/networks/:/",
+	"/notifications/threads/:",
+	"/orgs/:/events",
+	"/orgs/:/issues",
+	"/orgs/:/members",
+	"/orgs/:/public_members",
+	"/orgs/:/teams",
+	"/orgs/:/repos",
+	"/users/:",
+	"/user/starred",
+	"/user/subscriptions",
+	"/user/followers",
+	"/user/following",
+	"/user/keys/",
+	"/gists/public",
+	"/gists/starred",
+	"/gists/:",
+	"/gitignore/templates/:",
+	"/teams/:/members",
+	"/teams/:/repos",
+	"/legacy/issues/search/:/",
+	"/legacy/user/search/:",
+	"/legacy/user/email/:",
+	"/authorizations/clients/:",
+	"/applications/:/tokens/",
+	"/repos/:/",
+	"/networks/:/:",
+	"/notifications/threads/:/subscription",
+	"/orgs/:/members/",
+	"/orgs/:/public_members/",
+	"/users/:/",
+	"/user/starred/",
+	"/user/subscriptions/",
+	"/user/following/",
+	"/user/keys/:",
+	"/gists/:/",
+	"/teams/:/members/",
+	"/teams/:/repos/",
+	"/legacy/issues/search/:/:",
+	"/applications/:/tokens/:",
+	"/repos/:/:",
+	"/networks/:/:/events",
+	"/orgs/:/members/:",
+	"/orgs/:/public_members/:",
+	"/users/:/re",
+	"/users/:/events",
+	"/users/:/s",
+	"/users/:/gists",
+	"/users/:/orgs",
+	"/users/:/follow",
+	"/users/:/keys",
+	"/user/starred/:",
+	"/user/subscriptions/:",
+	"/user/following/:",
+	"/gists/:/star",
+	"/gists/:/forks",
+	"/teams/:/members/:",
+	"/teams/:/repos/:",
+	"/legacy/issues/search/:/:/",
+	"/repos/:/:/",
+	"/users/:/received_events",
+	"/users/:/repos",
+	"/users/:/events/",
+	"/users/:/starred",
+	"/users/:/subscriptions",
+	"/users/:/followers",
+	"/users/:/following",
+	"/user/starred/:/",
+	"/user/subscriptions/:/",
+	"/teams/:/repos/:/",
+	"/legacy/issues/search/:/:/:",
+	"/repos/:/:/events",
+	"/repos/:/:/notifications",
+	"/repos/:/:/s",
+	"/repos/:/:/git/",
+	"/repos/:/:/issues",
+	"/repos/:/:/assignees",
+	"/repos/:/:/la",
+	"/repos/:/:/m",
+	"/repos/:/:/pulls",
+	"/repos/:/:/co",
+	"/repos/:/:/t",
+	"/repos/:/:/branches",
+	"/repos/:/:/re",
+	"/repos/:/:/:",
+	"/repos/:/:/keys",
+	"/repos/:/:/downloads",
+	"/repos/:/:/forks",
+	"/repos/:/:/hooks",
+	"/users/:/received_events/public",
+	"/users/:/events/public",
+	"/users/:/events/orgs/",
+	"/users/:/following/",
+	"/user/starred/:/:",
+	"/user/subscriptions/:/:",
+	"/teams/:/repos/:/:",
+	"/legacy/issues/search/:/:/:/",
+	"/repos/:/:/sta",
+	"/repos/:/:/subscri",
+	"/repos/:/:/git/blobs",
+	"/repos/:/:/git/commits",
+	"/repos/:/:/git/refs",
+	"/repos/:/:/git/t",
+	"/repos/:/:/issues/",
+	"/repos/:/:/assignees/",
+	"/repos/:/:/labels",
+	"/repos/:/:/languages",
+	"/repos/:/:/milestones",
+	"/repos/:/:/merges",
+	"/repos/:/:/pulls/",
+	"/repos/:/:/cont",
+	"/repos/:/:/collaborators",
+	"/repos/:/:/comm",
+	"/repos/:/:/teams",
+	"/repos/:/:/tags",
+	"/repos/:/:/branches/",
+	"/repos/:/:/readme",
+	"/repos/:/:/releases",
+	"/repos/:/:/:/",
+	"/repos/:/:/keys/",
+	"/repos/:/:/downloads/",
+	"/repos/:/:/hooks/",
+	"/users/:/events/orgs/:",
+	"/users/:/following/:",
+	"/legacy/issues/search/:/:/:/:",
+	"/repos/:/:/stargazers",
+	"/repos/:/:/stat",
+	"/repos/:/:/subscribers",
+	"/repos/:/:/subscription",
+	"/repos/:/:/git/blobs/",
+	"/repos/:/:/git/commits/",
+	"/repos/:/:/git/refs/",
+	"/repos/:/:/git/tags",
+	"/repos/:/:/git/trees",
+	"/repos/:/:/issues/:",
+	"/repos/:/:/issues/comments",
+	"/repos/:/:/issues/events",
+	"/repos/:/:/assignees/:",
+	"/repos/:/:/labels/",
+	"/repos/:/:/milestones/",
+	"/repos/:/:/pulls/:",
+	"/repos/:/:/pulls/comments",
+	"/repos/:/:/contributors",
+	"/repos/:/:/contents/",
+	"/repos/:/:/collaborators/",
+	"/repos/:/:/comments",
+	"/repos/:/:/commits",
+	"/repos/:/:/branches/:",
+	"/repos/:/:/releases/",
+	"/repos/:/:/:/:",
+	"/repos/:/:/keys/:",
+	"/repos/:/:/downloads/:",
+	"/repos/:/:/hooks/:",
+	"/repos/:/:/stats/",
+	"/repos/:/:/statuses/",
+	"/repos/:/:/git/blobs/:",
+	"/repos/:/:/git/commits/:",
+	"/repos/:/:/git/refs/*",
+	"/repos/:/:/git/tags/",
+	"/repos/:/:/git/trees/",
+	"/repos/:/:/issues/:/",
+	"/repos/:/:/issues/comments/",
+	"/repos/:/:/issues/events/",
+	"/repos/:/:/labels/:",
+	"/repos/:/:/milestones/:",
+	"/repos/:/:/pulls/:/",
+	"/repos/:/:/pulls/comments/",
+	"/repos/:/:/contents/*",
+	"/repos/:/:/collaborators/:",
+	"/repos/:/:/comments/",
+	"/repos/:/:/commits/",
+	"/repos/:/:/releases/:",
+	"/repos/:/:/hooks/:/tests",
+	"/repos/:/:/stats/co",
+	"/repos/:/:/stats/p",
+	"/repos/:/:/statuses/:",
+	"/repos/:/:/git/tags/:",
+	"/repos/:/:/git/trees/:",
+	"/repos/:/:/issues/:/comments",
+	"/repos/:/:/issues/:/events",
+	"/repos/:/:/issues/:/labels",
+	"/repos/:/:/issues/comments/:",
+	"/repos/:/:/issues/events/:",
+	"/repos/:/:/milestones/:/labels",
+	"/repos/:/:/pulls/:/comm",
+	"/repos/:/:/pulls/:/files",
+	"/repos/:/:/pulls/:/merge",
+	"/repos/:/:/pulls/comments/:",
+	"/repos/:/:/comments/:",
+	"/repos/:/:/commits/:",
+	"/repos/:/:/releases/:/assets",
+	"/repos/:/:/stats/contributors",
+	"/repos/:/:/stats/commit_activity",
+	"/repos/:/:/stats/code_frequency",
+	"/repos/:/:/stats/participation",
+	"/repos/:/:/stats/punch_card",
+	"/repos/:/:/issues/:/labels/",
+	"/repos/:/:/pulls/:/commits",
+	"/repos/:/:/pulls/:/comments",
+	"/repos/:/:/commits/:/comments",
+	"/repos/:/:/issues/:/labels/:",
+}
+
+var matcherPrefix = [...]string{
+	"/",
+	"a",
+	"e",
+	"r",
+	"n",
+	"orgs/",
+	"user",
+	"feeds",
+	"gi",
+	"issues",
+	"m",
+	"teams/",
+	"search/",
+	"legacy/",
+	"uthorizations",
+	"pplications/",
+	"vents",
+	"mojis",
+	"epos",
+	"ate_limit",
+	"etworks/",
+	"otifications",
+	":",
+	"s",
+	"/",
+	"sts",
+	"tignore/templates",
+	"arkdown",
+	"eta",
+	":",
+	"repositories",
+	"code",
+	"issues",
+	"users",
+	"issues/search/",
+	"repos/search/",
+	"user/",
+	"/",
+	":",
+	"/",
+	"itories",
+	":",
+	"/threads/",
+	"/",
+	"/",
+	"s",
+	"issues",
+	"orgs",
+	"teams",
+	"repos",
+	"emails",
+	"follow",
+	"keys",
+	"/",
+	"/",
+	"/raw",
+	"/",
+	":",
+	":",
+	"search/",
+	"email/",
+	":",
+	"clients/",
+	"/tokens",
+	":",
+	"/",
+	":",
+	"events",
+	"issues",
+	"members",
+	"public_members",
+	"teams",
+	"repos",
+	":",
+	"tarred",
+	"ubscriptions",
+	"ers",
+	"ing",
+	"/",
+	"public",
+	"starred",
+	":",
+	":",
+	"members",
+	"repos",
+	"/",
+	":",
+	":",
+	":",
+	"/",
+	"/",
+	":",
+	"/subscription",
+	"/",
+	"/",
+	"/",
+	"/",
+	"/",
+	"/",
+	":",
+	"/",
+	"/",
+	"/",
+	":",
+	":",
+	":",
+	"/events",
+	":",
+	":",
+	"re",
+	"events",
+	"s",
+	"gists",
+	"orgs",
+	"follow",
+	"keys",
+	":",
+	":",
+	":",
+	"star",
+	"forks",
+	":",
+	":",
+	"/",
+	"/",
+	"ceived_events",
+	"pos",
+	"/",
+	"tarred",
+	"ubscriptions",
+	"ers",
+	"ing",
+	"/",
+	"/",
+	"/",
+	":",
+	"events",
+	"notifications",
+	"s",
+	"git/",
+	"issues",
+	"assignees",
+	"la",
+	"m",
+	"pulls",
+	"co",
+	"t",
+	"branches",
+	"re",
+	":",
+	"keys",
+	"downloads",
+	"forks",
+	"hooks",
+	"/public",
+	"public",
+	"orgs/",
+	"/",
+	":",
+	":",
+	":",
+	"/",
+	"ta",
+	"ubscri",
+	"blobs",
+	"commits",
+	"refs",
+	"t",
+	"/",
+	"/",
+	"bels",
+	"nguages",
+	"ilestones",
+	"erges",
+	"/",
+	"nt",
+	"llaborators",
+	"mm",
+	"eams",
+	"ags",
+	"/",
+	"adme",
+	"leases",
+	"/",
+	"/",
+	"/",
+	"/",
+	":",
+	":",
+	":",
+	"rgazers",
+	"t",
+	"bers",
+	"ption",
+	"/",
+	"/",
+	"/",
+	"ags",
+	"rees",
+	":",
+	"comments",
+	"events",
+	":",
+	"/",
+	"/",
+	":",
+	"comments",
+	"ributors",
+	"ents/",
+	"/",
+	"ents",
+	"its",
+	":",
+	"/",
+	":",
+	":",
+	":",
+	":",
+	"s/",
+	"uses/",
+	":",
+	":",
+	"*",
+	"/",
+	"/",
+	"/",
+	"/",
+	"/",
+	":",
+	":",
+	"/",
+	"/",
+	"*",
+	":",
+	"/",
+	"/",
+	":",
+	"/tests",
+	"co",
+	"p",
+	":",
+	":",
+	":",
+	"comments",
+	"events",
+	"labels",
+	":",
+	":",
+	"/labels",
+	"comm",
+	"files",
+	"merge",
+	":",
+	":",
+	":",
+	"/assets",
+	"ntributors",
+	"mmit_activity",
+	"de_frequency",
+	"articipation",
+	"unch_card",
+	"/",
+	"its",
+	"ents",
+	"/comments",
+	":",
+}
+
+var matcherParent = [...]int{
+	-1,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	0,
+	1,
+	1,
+	2,
+	2,
+	3,
+	3,
+	4,
+	4,
+	5,
+	6,
+	6,
+	8,
+	8,
+	10,
+	10,
+	11,
+	12,
+	12,
+	12,
+	12,
+	13,
+	13,
+	13,
+	14,
+	15,
+	18,
+	18,
+	20,
+	21,
+	22,
+	23,
+	24,
+	24,
+	24,
+	24,
+	24,
+	24,
+	24,
+	24,
+	25,
+	26,
+	27,
+	29,
+	34,
+	35,
+	36,
+	36,
+	37,
+	37,
+	38,
+	39,
+	41,
+	42,
+	43,
+	43,
+	43,
+	43,
+	43,
+	43,
+	44,
+	45,
+	45,
+	51,
+	51,
+	52,
+	53,
+	53,
+	53,
+	54,
+	56,
+	56,
+	57,
+	59,
+	60,
+	62,
+	63,
+	64,
+	65,
+	66,
+	69,
+	70,
+	73,
+	74,
+	75,
+	77,
+	78,
+	81,
+	83,
+	84,
+	85,
+	89,
+	90,
+	91,
+	93,
+	94,
+	95,
+	95,
+	95,
+	95,
+	95,
+	95,
+	95,
+	96,
+	97,
+	98,
+	100,
+	100,
+	101,
+	102,
+	103,
+	105,
+	109,
+	109,
+	110,
+	111,
+	111,
+	114,
+	114,
+	116,
+	117,
+	122,
+	123,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	124,
+	125,
+	127,
+	127,
+	131,
+	132,
+	133,
+	134,
+	135,
+	138,
+	138,
+	139,
+	139,
+	139,
+	139,
+	140,
+	141,
+	142,
+	142,
+	143,
+	143,
+	144,
+	145,
+	145,
+	145,
+	146,
+	146,
+	147,
+	148,
+	148,
+	149,
+	150,
+	151,
+	153,
+	156,
+	157,
+	161,
+	162,
+	162,
+	163,
+	163,
+	164,
+	165,
+	166,
+	167,
+	167,
+	168,
+	168,
+	168,
+	169,
+	170,
+	172,
+	174,
+	174,
+	175,
+	175,
+	176,
+	177,
+	177,
+	180,
+	182,
+	183,
+	184,
+	185,
+	186,
+	191,
+	191,
+	194,
+	195,
+	196,
+	197,
+	198,
+	199,
+	200,
+	201,
+	203,
+	204,
+	205,
+	206,
+	208,
+	209,
+	210,
+	211,
+	213,
+	217,
+	218,
+	218,
+	219,
+	223,
+	224,
+	225,
+	225,
+	225,
+	226,
+	227,
+	229,
+	230,
+	230,
+	230,
+	231,
+	234,
+	235,
+	236,
+	238,
+	238,
+	238,
+	239,
+	239,
+	245,
+	249,
+	249,
+	254,
+	261,
+}
+
+var matcherParam = [...]int{
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	22,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	29,
+	-1,
+	-1,
+	-1,
+	38,
+	-1,
+	-1,
+	-1,
+	-1,
+	41,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	57,
+	58,
+	-1,
+	61,
+	-1,
+	64,
+	-1,
+	-1,
+	66,
+	-1,
+	73,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	81,
+	82,
+	-1,
+	-1,
+	-1,
+	-1,
+	86,
+	87,
+	-1,
+	88,
+	-1,
+	-1,
+	91,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	99,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	103,
+	-1,
+	-1,
+	-1,
+	104,
+	105,
+	-1,
+	-1,
+	107,
+	108,
+	-1,
+	116,
+	117,
+	118,
+	-1,
+	-1,
+	121,
+	122,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	135,
+	149,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	158,
+	159,
+	160,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	187,
+	188,
+	-1,
+	-1,
+	-1,
+	189,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	199,
+	202,
+	-1,
+	-1,
+	-1,
+	-1,
+	205,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	212,
+	-1,
+	-1,
+	214,
+	215,
+	216,
+	217,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	220,
+	221,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	228,
+	229,
+	-1,
+	-1,
+	-1,
+	-1,
+	233,
+	-1,
+	-1,
+	-1,
+	236,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	240,
+	-1,
+	-1,
+	-1,
+	241,
+	242,
+	-1,
+	246,
+	247,
+	-1,
+	-1,
+	-1,
+	252,
+	-1,
+	-1,
+	253,
+	254,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	265,
+	-1,
+	-1,
+	-1,
+	-1,
+}
+
+var matcherAny = [...]int{
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	222,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	232,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+	-1,
+}
+
+var matcherParamLabel = [...]bool{
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	true,
+	false,
+	false,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+}
+
+var matcherAnyLabel = [...]bool{
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+}
+
+var matcherLeaf = [...]bool{
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	true,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	true,
+	false,
+	false,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	false,
+	true,
+	true,
+	false,
+	false,
+	false,
+	true,
+	false,
+	false,
+	true,
+	true,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	true,
+	true,
+	true,
+	true,
+	false,
+	true,
+	true,
+	true,
+	true,
+}
+
+// Nodes - the keys of the nodes of the router tree the matcher was generated for
+func (Matcher) Nodes() []string {
+	return matcherNodes
+}
+
+// Match - match the path, returning the index of the node it matches
+func (Matcher) Match(path string, values []string) (int, []string) {
+	var (
+		n         = 0
+		search    = path
+		tmpsearch string
+	)
+	for {
+		if search == "" {
+			return n, values
+		}
+
+		// the prefix of the node
+		switch n {
+		case 0:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 1:
+			if strings.HasPrefix(search, "a") {
+				search = search[1:]
+			}
+		case 2:
+			if strings.HasPrefix(search, "e") {
+				search = search[1:]
+			}
+		case 3:
+			if strings.HasPrefix(search, "r") {
+				search = search[1:]
+			}
+		case 4:
+			if strings.HasPrefix(search, "n") {
+				search = search[1:]
+			}
+		case 5:
+			if strings.HasPrefix(search, "orgs/") {
+				search = search[5:]
+			}
+		case 6:
+			if strings.HasPrefix(search, "user") {
+				search = search[4:]
+			}
+		case 7:
+			if strings.HasPrefix(search, "feeds") {
+				search = search[5:]
+			}
+		case 8:
+			if strings.HasPrefix(search, "gi") {
+				search = search[2:]
+			}
+		case 9:
+			if strings.HasPrefix(search, "issues") {
+				search = search[6:]
+			}
+		case 10:
+			if strings.HasPrefix(search, "m") {
+				search = search[1:]
+			}
+		case 11:
+			if strings.HasPrefix(search, "teams/") {
+				search = search[6:]
+			}
+		case 12:
+			if strings.HasPrefix(search, "search/") {
+				search = search[7:]
+			}
+		case 13:
+			if strings.HasPrefix(search, "legacy/") {
+				search = search[7:]
+			}
+		case 14:
+			if strings.HasPrefix(search, "uthorizations") {
+				search = search[13:]
+			}
+		case 15:
+			if strings.HasPrefix(search, "pplications/") {
+				search = search[12:]
+			}
+		case 16:
+			if strings.HasPrefix(search, "vents") {
+				search = search[5:]
+			}
+		case 17:
+			if strings.HasPrefix(search, "mojis") {
+				search = search[5:]
+			}
+		case 18:
+			if strings.HasPrefix(search, "epos") {
+				search = search[4:]
+			}
+		case 19:
+			if strings.HasPrefix(search, "ate_limit") {
+				search = search[9:]
+			}
+		case 20:
+			if strings.HasPrefix(search, "etworks/") {
+				search = search[8:]
+			}
+		case 21:
+			if strings.HasPrefix(search, "otifications") {
+				search = search[12:]
+			}
+		case 23:
+			if strings.HasPrefix(search, "s") {
+				search = search[1:]
+			}
+		case 24:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 25:
+			if strings.HasPrefix(search, "sts") {
+				search = search[3:]
+			}
+		case 26:
+			if strings.HasPrefix(search, "tignore/templates") {
+				search = search[17:]
+			}
+		case 27:
+			if strings.HasPrefix(search, "arkdown") {
+				search = search[7:]
+			}
+		case 28:
+			if strings.HasPrefix(search, "eta") {
+				search = search[3:]
+			}
+		case 30:
+			if strings.HasPrefix(search, "repositories") {
+				search = search[12:]
+			}
+		case 31:
+			if strings.HasPrefix(search, "code") {
+				search = search[4:]
+			}
+		case 32:
+			if strings.HasPrefix(search, "issues") {
+				search = search[6:]
+			}
+		case 33:
+			if strings.HasPrefix(search, "users") {
+				search = search[5:]
+			}
+		case 34:
+			if strings.HasPrefix(search, "issues/search/") {
+				search = search[14:]
+			}
+		case 35:
+			if strings.HasPrefix(search, "repos/search/") {
+				search = search[13:]
+			}
+		case 36:
+			if strings.HasPrefix(search, "user/") {
+				search = search[5:]
+			}
+		case 37:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 39:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 40:
+			if strings.HasPrefix(search, "itories") {
+				search = search[7:]
+			}
+		case 42:
+			if strings.HasPrefix(search, "/threads/") {
+				search = search[9:]
+			}
+		case 43:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 44:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 45:
+			if strings.HasPrefix(search, "s") {
+				search = search[1:]
+			}
+		case 46:
+			if strings.HasPrefix(search, "issues") {
+				search = search[6:]
+			}
+		case 47:
+			if strings.HasPrefix(search, "orgs") {
+				search = search[4:]
+			}
+		case 48:
+			if strings.HasPrefix(search, "teams") {
+				search = search[5:]
+			}
+		case 49:
+			if strings.HasPrefix(search, "repos") {
+				search = search[5:]
+			}
+		case 50:
+			if strings.HasPrefix(search, "emails") {
+				search = search[6:]
+			}
+		case 51:
+			if strings.HasPrefix(search, "follow") {
+				search = search[6:]
+			}
+		case 52:
+			if strings.HasPrefix(search, "keys") {
+				search = search[4:]
+			}
+		case 53:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 54:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 55:
+			if strings.HasPrefix(search, "/raw") {
+				search = search[4:]
+			}
+		case 56:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 59:
+			if strings.HasPrefix(search, "search/") {
+				search = search[7:]
+			}
+		case 60:
+			if strings.HasPrefix(search, "email/") {
+				search = search[6:]
+			}
+		case 62:
+			if strings.HasPrefix(search, "clients/") {
+				search = search[8:]
+			}
+		case 63:
+			if strings.HasPrefix(search, "/tokens") {
+				search = search[7:]
+			}
+		case 65:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 67:
+			if strings.HasPrefix(search, "events") {
+				search = search[6:]
+			}
+		case 68:
+			if strings.HasPrefix(search, "issues") {
+				search = search[6:]
+			}
+		case 69:
+			if strings.HasPrefix(search, "members") {
+				search = search[7:]
+			}
+		case 70:
+			if strings.HasPrefix(search, "public_members") {
+				search = search[14:]
+			}
+		case 71:
+			if strings.HasPrefix(search, "teams") {
+				search = search[5:]
+			}
+		case 72:
+			if strings.HasPrefix(search, "repos") {
+				search = search[5:]
+			}
+		case 74:
+			if strings.HasPrefix(search, "tarred") {
+				search = search[6:]
+			}
+		case 75:
+			if strings.HasPrefix(search, "ubscriptions") {
+				search = search[12:]
+			}
+		case 76:
+			if strings.HasPrefix(search, "ers") {
+				search = search[3:]
+			}
+		case 77:
+			if strings.HasPrefix(search, "ing") {
+				search = search[3:]
+			}
+		case 78:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 79:
+			if strings.HasPrefix(search, "public") {
+				search = search[6:]
+			}
+		case 80:
+			if strings.HasPrefix(search, "starred") {
+				search = search[7:]
+			}
+		case 83:
+			if strings.HasPrefix(search, "members") {
+				search = search[7:]
+			}
+		case 84:
+			if strings.HasPrefix(search, "repos") {
+				search = search[5:]
+			}
+		case 85:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 89:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 90:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 92:
+			if strings.HasPrefix(search, "/subscription") {
+				search = search[13:]
+			}
+		case 93:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 94:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 95:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 96:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 97:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 98:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 100:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 101:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 102:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 106:
+			if strings.HasPrefix(search, "/events") {
+				search = search[7:]
+			}
+		case 109:
+			if strings.HasPrefix(search, "re") {
+				search = search[2:]
+			}
+		case 110:
+			if strings.HasPrefix(search, "events") {
+				search = search[6:]
+			}
+		case 111:
+			if strings.HasPrefix(search, "s") {
+				search = search[1:]
+			}
+		case 112:
+			if strings.HasPrefix(search, "gists") {
+				search = search[5:]
+			}
+		case 113:
+			if strings.HasPrefix(search, "orgs") {
+				search = search[4:]
+			}
+		case 114:
+			if strings.HasPrefix(search, "follow") {
+				search = search[6:]
+			}
+		case 115:
+			if strings.HasPrefix(search, "keys") {
+				search = search[4:]
+			}
+		case 119:
+			if strings.HasPrefix(search, "star") {
+				search = search[4:]
+			}
+		case 120:
+			if strings.HasPrefix(search, "forks") {
+				search = search[5:]
+			}
+		case 123:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 124:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 125:
+			if strings.HasPrefix(search, "ceived_events") {
+				search = search[13:]
+			}
+		case 126:
+			if strings.HasPrefix(search, "pos") {
+				search = search[3:]
+			}
+		case 127:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 128:
+			if strings.HasPrefix(search, "tarred") {
+				search = search[6:]
+			}
+		case 129:
+			if strings.HasPrefix(search, "ubscriptions") {
+				search = search[12:]
+			}
+		case 130:
+			if strings.HasPrefix(search, "ers") {
+				search = search[3:]
+			}
+		case 131:
+			if strings.HasPrefix(search, "ing") {
+				search = search[3:]
+			}
+		case 132:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 133:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 134:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 136:
+			if strings.HasPrefix(search, "events") {
+				search = search[6:]
+			}
+		case 137:
+			if strings.HasPrefix(search, "notifications") {
+				search = search[13:]
+			}
+		case 138:
+			if strings.HasPrefix(search, "s") {
+				search = search[1:]
+			}
+		case 139:
+			if strings.HasPrefix(search, "git/") {
+				search = search[4:]
+			}
+		case 140:
+			if strings.HasPrefix(search, "issues") {
+				search = search[6:]
+			}
+		case 141:
+			if strings.HasPrefix(search, "assignees") {
+				search = search[9:]
+			}
+		case 142:
+			if strings.HasPrefix(search, "la") {
+				search = search[2:]
+			}
+		case 143:
+			if strings.HasPrefix(search, "m") {
+				search = search[1:]
+			}
+		case 144:
+			if strings.HasPrefix(search, "pulls") {
+				search = search[5:]
+			}
+		case 145:
+			if strings.HasPrefix(search, "co") {
+				search = search[2:]
+			}
+		case 146:
+			if strings.HasPrefix(search, "t") {
+				search = search[1:]
+			}
+		case 147:
+			if strings.HasPrefix(search, "branches") {
+				search = search[8:]
+			}
+		case 148:
+			if strings.HasPrefix(search, "re") {
+				search = search[2:]
+			}
+		case 150:
+			if strings.HasPrefix(search, "keys") {
+				search = search[4:]
+			}
+		case 151:
+			if strings.HasPrefix(search, "downloads") {
+				search = search[9:]
+			}
+		case 152:
+			if strings.HasPrefix(search, "forks") {
+				search = search[5:]
+			}
+		case 153:
+			if strings.HasPrefix(search, "hooks") {
+				search = search[5:]
+			}
+		case 154:
+			if strings.HasPrefix(search, "/public") {
+				search = search[7:]
+			}
+		case 155:
+			if strings.HasPrefix(search, "public") {
+				search = search[6:]
+			}
+		case 156:
+			if strings.HasPrefix(search, "orgs/") {
+				search = search[5:]
+			}
+		case 157:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 161:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 162:
+			if strings.HasPrefix(search, "ta") {
+				search = search[2:]
+			}
+		case 163:
+			if strings.HasPrefix(search, "ubscri") {
+				search = search[6:]
+			}
+		case 164:
+			if strings.HasPrefix(search, "blobs") {
+				search = search[5:]
+			}
+		case 165:
+			if strings.HasPrefix(search, "commits") {
+				search = search[7:]
+			}
+		case 166:
+			if strings.HasPrefix(search, "refs") {
+				search = search[4:]
+			}
+		case 167:
+			if strings.HasPrefix(search, "t") {
+				search = search[1:]
+			}
+		case 168:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 169:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 170:
+			if strings.HasPrefix(search, "bels") {
+				search = search[4:]
+			}
+		case 171:
+			if strings.HasPrefix(search, "nguages") {
+				search = search[7:]
+			}
+		case 172:
+			if strings.HasPrefix(search, "ilestones") {
+				search = search[9:]
+			}
+		case 173:
+			if strings.HasPrefix(search, "erges") {
+				search = search[5:]
+			}
+		case 174:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 175:
+			if strings.HasPrefix(search, "nt") {
+				search = search[2:]
+			}
+		case 176:
+			if strings.HasPrefix(search, "llaborators") {
+				search = search[11:]
+			}
+		case 177:
+			if strings.HasPrefix(search, "mm") {
+				search = search[2:]
+			}
+		case 178:
+			if strings.HasPrefix(search, "eams") {
+				search = search[4:]
+			}
+		case 179:
+			if strings.HasPrefix(search, "ags") {
+				search = search[3:]
+			}
+		case 180:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 181:
+			if strings.HasPrefix(search, "adme") {
+				search = search[4:]
+			}
+		case 182:
+			if strings.HasPrefix(search, "leases") {
+				search = search[6:]
+			}
+		case 183:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 184:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 185:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 186:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 190:
+			if strings.HasPrefix(search, "rgazers") {
+				search = search[7:]
+			}
+		case 191:
+			if strings.HasPrefix(search, "t") {
+				search = search[1:]
+			}
+		case 192:
+			if strings.HasPrefix(search, "bers") {
+				search = search[4:]
+			}
+		case 193:
+			if strings.HasPrefix(search, "ption") {
+				search = search[5:]
+			}
+		case 194:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 195:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 196:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 197:
+			if strings.HasPrefix(search, "ags") {
+				search = search[3:]
+			}
+		case 198:
+			if strings.HasPrefix(search, "rees") {
+				search = search[4:]
+			}
+		case 200:
+			if strings.HasPrefix(search, "comments") {
+				search = search[8:]
+			}
+		case 201:
+			if strings.HasPrefix(search, "events") {
+				search = search[6:]
+			}
+		case 203:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 204:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 206:
+			if strings.HasPrefix(search, "comments") {
+				search = search[8:]
+			}
+		case 207:
+			if strings.HasPrefix(search, "ributors") {
+				search = search[8:]
+			}
+		case 208:
+			if strings.HasPrefix(search, "ents/") {
+				search = search[5:]
+			}
+		case 209:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 210:
+			if strings.HasPrefix(search, "ents") {
+				search = search[4:]
+			}
+		case 211:
+			if strings.HasPrefix(search, "its") {
+				search = search[3:]
+			}
+		case 213:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 218:
+			if strings.HasPrefix(search, "s/") {
+				search = search[2:]
+			}
+		case 219:
+			if strings.HasPrefix(search, "uses/") {
+				search = search[5:]
+			}
+		case 222:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 223:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 224:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 225:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 226:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 227:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 230:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 231:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 232:
+			if strings.HasPrefix(search, "*") {
+				search = search[1:]
+			}
+		case 234:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 235:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 237:
+			if strings.HasPrefix(search, "/tests") {
+				search = search[6:]
+			}
+		case 238:
+			if strings.HasPrefix(search, "co") {
+				search = search[2:]
+			}
+		case 239:
+			if strings.HasPrefix(search, "p") {
+				search = search[1:]
+			}
+		case 243:
+			if strings.HasPrefix(search, "comments") {
+				search = search[8:]
+			}
+		case 244:
+			if strings.HasPrefix(search, "events") {
+				search = search[6:]
+			}
+		case 245:
+			if strings.HasPrefix(search, "labels") {
+				search = search[6:]
+			}
+		case 248:
+			if strings.HasPrefix(search, "/labels") {
+				search = search[7:]
+			}
+		case 249:
+			if strings.HasPrefix(search, "comm") {
+				search = search[4:]
+			}
+		case 250:
+			if strings.HasPrefix(search, "files") {
+				search = search[5:]
+			}
+		case 251:
+			if strings.HasPrefix(search, "merge") {
+				search = search[5:]
+			}
+		case 255:
+			if strings.HasPrefix(search, "/assets") {
+				search = search[7:]
+			}
+		case 256:
+			if strings.HasPrefix(search, "ntributors") {
+				search = search[10:]
+			}
+		case 257:
+			if strings.HasPrefix(search, "mmit_activity") {
+				search = search[13:]
+			}
+		case 258:
+			if strings.HasPrefix(search, "de_frequency") {
+				search = search[12:]
+			}
+		case 259:
+			if strings.HasPrefix(search, "articipation") {
+				search = search[12:]
+			}
+		case 260:
+			if strings.HasPrefix(search, "unch_card") {
+				search = search[9:]
+			}
+		case 261:
+			if strings.HasPrefix(search, "/") {
+				search = search[1:]
+			}
+		case 262:
+			if strings.HasPrefix(search, "its") {
+				search = search[3:]
+			}
+		case 263:
+			if strings.HasPrefix(search, "ents") {
+				search = search[4:]
+			}
+		case 264:
+			if strings.HasPrefix(search, "/comments") {
+				search = search[9:]
+			}
+		}
+
+		if search == "" {
+			if matcherAny[n] < 0 {
+				continue
+			}
+			goto matchAny
+		}
+
+		// static children
+		switch n {
+		case 0:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "a") {
+					n = 1
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "e") {
+					n = 2
+					continue
+				}
+			case 'f':
+				if strings.HasPrefix(search, "feeds") {
+					n = 7
+					continue
+				}
+			case 'g':
+				if strings.HasPrefix(search, "gi") {
+					n = 8
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "issues") {
+					n = 9
+					continue
+				}
+			case 'l':
+				if strings.HasPrefix(search, "legacy/") {
+					n = 13
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "m") {
+					n = 10
+					continue
+				}
+			case 'n':
+				if strings.HasPrefix(search, "n") {
+					n = 4
+					continue
+				}
+			case 'o':
+				if strings.HasPrefix(search, "orgs/") {
+					n = 5
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "r") {
+					n = 3
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "search/") {
+					n = 12
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "teams/") {
+					n = 11
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "user") {
+					n = 6
+					continue
+				}
+			}
+		case 1:
+			switch search[0] {
+			case 'p':
+				if strings.HasPrefix(search, "pplications/") {
+					n = 15
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "uthorizations") {
+					n = 14
+					continue
+				}
+			}
+		case 2:
+			switch search[0] {
+			case 'm':
+				if strings.HasPrefix(search, "mojis") {
+					n = 17
+					continue
+				}
+			case 'v':
+				if strings.HasPrefix(search, "vents") {
+					n = 16
+					continue
+				}
+			}
+		case 3:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "ate_limit") {
+					n = 19
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "epos") {
+					n = 18
+					continue
+				}
+			}
+		case 4:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "etworks/") {
+					n = 20
+					continue
+				}
+			case 'o':
+				if strings.HasPrefix(search, "otifications") {
+					n = 21
+					continue
+				}
+			}
+		case 6:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 24
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "s") {
+					n = 23
+					continue
+				}
+			}
+		case 8:
+			switch search[0] {
+			case 's':
+				if strings.HasPrefix(search, "sts") {
+					n = 25
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "tignore/templates") {
+					n = 26
+					continue
+				}
+			}
+		case 10:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "arkdown") {
+					n = 27
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "eta") {
+					n = 28
+					continue
+				}
+			}
+		case 12:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "code") {
+					n = 31
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "issues") {
+					n = 32
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "repositories") {
+					n = 30
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "users") {
+					n = 33
+					continue
+				}
+			}
+		case 13:
+			switch search[0] {
+			case 'i':
+				if strings.HasPrefix(search, "issues/search/") {
+					n = 34
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "repos/search/") {
+					n = 35
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "user/") {
+					n = 36
+					continue
+				}
+			}
+		case 14:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 37
+					continue
+				}
+			}
+		case 18:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 39
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "itories") {
+					n = 40
+					continue
+				}
+			}
+		case 21:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/threads/") {
+					n = 42
+					continue
+				}
+			}
+		case 22:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 43
+					continue
+				}
+			}
+		case 23:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 44
+					continue
+				}
+			}
+		case 24:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "emails") {
+					n = 50
+					continue
+				}
+			case 'f':
+				if strings.HasPrefix(search, "follow") {
+					n = 51
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "issues") {
+					n = 46
+					continue
+				}
+			case 'k':
+				if strings.HasPrefix(search, "keys") {
+					n = 52
+					continue
+				}
+			case 'o':
+				if strings.HasPrefix(search, "orgs") {
+					n = 47
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "repos") {
+					n = 49
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "s") {
+					n = 45
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "teams") {
+					n = 48
+					continue
+				}
+			}
+		case 25:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 53
+					continue
+				}
+			}
+		case 26:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 54
+					continue
+				}
+			}
+		case 27:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/raw") {
+					n = 55
+					continue
+				}
+			}
+		case 29:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 56
+					continue
+				}
+			}
+		case 36:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "email/") {
+					n = 60
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "search/") {
+					n = 59
+					continue
+				}
+			}
+		case 37:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "clients/") {
+					n = 62
+					continue
+				}
+			}
+		case 38:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/tokens") {
+					n = 63
+					continue
+				}
+			}
+		case 41:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 65
+					continue
+				}
+			}
+		case 43:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "events") {
+					n = 67
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "issues") {
+					n = 68
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "members") {
+					n = 69
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "public_members") {
+					n = 70
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "repos") {
+					n = 72
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "teams") {
+					n = 71
+					continue
+				}
+			}
+		case 45:
+			switch search[0] {
+			case 't':
+				if strings.HasPrefix(search, "tarred") {
+					n = 74
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "ubscriptions") {
+					n = 75
+					continue
+				}
+			}
+		case 51:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "ers") {
+					n = 76
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "ing") {
+					n = 77
+					continue
+				}
+			}
+		case 52:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 78
+					continue
+				}
+			}
+		case 53:
+			switch search[0] {
+			case 'p':
+				if strings.HasPrefix(search, "public") {
+					n = 79
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "starred") {
+					n = 80
+					continue
+				}
+			}
+		case 56:
+			switch search[0] {
+			case 'm':
+				if strings.HasPrefix(search, "members") {
+					n = 83
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "repos") {
+					n = 84
+					continue
+				}
+			}
+		case 57:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 85
+					continue
+				}
+			}
+		case 63:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 89
+					continue
+				}
+			}
+		case 64:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 90
+					continue
+				}
+			}
+		case 66:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/subscription") {
+					n = 92
+					continue
+				}
+			}
+		case 69:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 93
+					continue
+				}
+			}
+		case 70:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 94
+					continue
+				}
+			}
+		case 73:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 95
+					continue
+				}
+			}
+		case 74:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 96
+					continue
+				}
+			}
+		case 75:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 97
+					continue
+				}
+			}
+		case 77:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 98
+					continue
+				}
+			}
+		case 81:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 100
+					continue
+				}
+			}
+		case 83:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 101
+					continue
+				}
+			}
+		case 84:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 102
+					continue
+				}
+			}
+		case 91:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/events") {
+					n = 106
+					continue
+				}
+			}
+		case 95:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "events") {
+					n = 110
+					continue
+				}
+			case 'f':
+				if strings.HasPrefix(search, "follow") {
+					n = 114
+					continue
+				}
+			case 'g':
+				if strings.HasPrefix(search, "gists") {
+					n = 112
+					continue
+				}
+			case 'k':
+				if strings.HasPrefix(search, "keys") {
+					n = 115
+					continue
+				}
+			case 'o':
+				if strings.HasPrefix(search, "orgs") {
+					n = 113
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "re") {
+					n = 109
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "s") {
+					n = 111
+					continue
+				}
+			}
+		case 100:
+			switch search[0] {
+			case 'f':
+				if strings.HasPrefix(search, "forks") {
+					n = 120
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "star") {
+					n = 119
+					continue
+				}
+			}
+		case 103:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 123
+					continue
+				}
+			}
+		case 105:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 124
+					continue
+				}
+			}
+		case 109:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "ceived_events") {
+					n = 125
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "pos") {
+					n = 126
+					continue
+				}
+			}
+		case 110:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 127
+					continue
+				}
+			}
+		case 111:
+			switch search[0] {
+			case 't':
+				if strings.HasPrefix(search, "tarred") {
+					n = 128
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "ubscriptions") {
+					n = 129
+					continue
+				}
+			}
+		case 114:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "ers") {
+					n = 130
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "ing") {
+					n = 131
+					continue
+				}
+			}
+		case 116:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 132
+					continue
+				}
+			}
+		case 117:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 133
+					continue
+				}
+			}
+		case 122:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 134
+					continue
+				}
+			}
+		case 124:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "assignees") {
+					n = 141
+					continue
+				}
+			case 'b':
+				if strings.HasPrefix(search, "branches") {
+					n = 147
+					continue
+				}
+			case 'c':
+				if strings.HasPrefix(search, "co") {
+					n = 145
+					continue
+				}
+			case 'd':
+				if strings.HasPrefix(search, "downloads") {
+					n = 151
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "events") {
+					n = 136
+					continue
+				}
+			case 'f':
+				if strings.HasPrefix(search, "forks") {
+					n = 152
+					continue
+				}
+			case 'g':
+				if strings.HasPrefix(search, "git/") {
+					n = 139
+					continue
+				}
+			case 'h':
+				if strings.HasPrefix(search, "hooks") {
+					n = 153
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "issues") {
+					n = 140
+					continue
+				}
+			case 'k':
+				if strings.HasPrefix(search, "keys") {
+					n = 150
+					continue
+				}
+			case 'l':
+				if strings.HasPrefix(search, "la") {
+					n = 142
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "m") {
+					n = 143
+					continue
+				}
+			case 'n':
+				if strings.HasPrefix(search, "notifications") {
+					n = 137
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "pulls") {
+					n = 144
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "re") {
+					n = 148
+					continue
+				}
+			case 's':
+				if strings.HasPrefix(search, "s") {
+					n = 138
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "t") {
+					n = 146
+					continue
+				}
+			}
+		case 125:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/public") {
+					n = 154
+					continue
+				}
+			}
+		case 127:
+			switch search[0] {
+			case 'o':
+				if strings.HasPrefix(search, "orgs/") {
+					n = 156
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "public") {
+					n = 155
+					continue
+				}
+			}
+		case 131:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 157
+					continue
+				}
+			}
+		case 135:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 161
+					continue
+				}
+			}
+		case 138:
+			switch search[0] {
+			case 't':
+				if strings.HasPrefix(search, "ta") {
+					n = 162
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "ubscri") {
+					n = 163
+					continue
+				}
+			}
+		case 139:
+			switch search[0] {
+			case 'b':
+				if strings.HasPrefix(search, "blobs") {
+					n = 164
+					continue
+				}
+			case 'c':
+				if strings.HasPrefix(search, "commits") {
+					n = 165
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "refs") {
+					n = 166
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "t") {
+					n = 167
+					continue
+				}
+			}
+		case 140:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 168
+					continue
+				}
+			}
+		case 141:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 169
+					continue
+				}
+			}
+		case 142:
+			switch search[0] {
+			case 'b':
+				if strings.HasPrefix(search, "bels") {
+					n = 170
+					continue
+				}
+			case 'n':
+				if strings.HasPrefix(search, "nguages") {
+					n = 171
+					continue
+				}
+			}
+		case 143:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "erges") {
+					n = 173
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "ilestones") {
+					n = 172
+					continue
+				}
+			}
+		case 144:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 174
+					continue
+				}
+			}
+		case 145:
+			switch search[0] {
+			case 'l':
+				if strings.HasPrefix(search, "llaborators") {
+					n = 176
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "mm") {
+					n = 177
+					continue
+				}
+			case 'n':
+				if strings.HasPrefix(search, "nt") {
+					n = 175
+					continue
+				}
+			}
+		case 146:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "ags") {
+					n = 179
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "eams") {
+					n = 178
+					continue
+				}
+			}
+		case 147:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 180
+					continue
+				}
+			}
+		case 148:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "adme") {
+					n = 181
+					continue
+				}
+			case 'l':
+				if strings.HasPrefix(search, "leases") {
+					n = 182
+					continue
+				}
+			}
+		case 149:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 183
+					continue
+				}
+			}
+		case 150:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 184
+					continue
+				}
+			}
+		case 151:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 185
+					continue
+				}
+			}
+		case 153:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 186
+					continue
+				}
+			}
+		case 162:
+			switch search[0] {
+			case 'r':
+				if strings.HasPrefix(search, "rgazers") {
+					n = 190
+					continue
+				}
+			case 't':
+				if strings.HasPrefix(search, "t") {
+					n = 191
+					continue
+				}
+			}
+		case 163:
+			switch search[0] {
+			case 'b':
+				if strings.HasPrefix(search, "bers") {
+					n = 192
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "ption") {
+					n = 193
+					continue
+				}
+			}
+		case 164:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 194
+					continue
+				}
+			}
+		case 165:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 195
+					continue
+				}
+			}
+		case 166:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 196
+					continue
+				}
+			}
+		case 167:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "ags") {
+					n = 197
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "rees") {
+					n = 198
+					continue
+				}
+			}
+		case 168:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "comments") {
+					n = 200
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "events") {
+					n = 201
+					continue
+				}
+			}
+		case 170:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 203
+					continue
+				}
+			}
+		case 172:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 204
+					continue
+				}
+			}
+		case 174:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "comments") {
+					n = 206
+					continue
+				}
+			}
+		case 175:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "ents/") {
+					n = 208
+					continue
+				}
+			case 'r':
+				if strings.HasPrefix(search, "ributors") {
+					n = 207
+					continue
+				}
+			}
+		case 176:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 209
+					continue
+				}
+			}
+		case 177:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "ents") {
+					n = 210
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "its") {
+					n = 211
+					continue
+				}
+			}
+		case 182:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 213
+					continue
+				}
+			}
+		case 191:
+			switch search[0] {
+			case 's':
+				if strings.HasPrefix(search, "s/") {
+					n = 218
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "uses/") {
+					n = 219
+					continue
+				}
+			}
+		case 197:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 223
+					continue
+				}
+			}
+		case 198:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 224
+					continue
+				}
+			}
+		case 199:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 225
+					continue
+				}
+			}
+		case 200:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 226
+					continue
+				}
+			}
+		case 201:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 227
+					continue
+				}
+			}
+		case 205:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 230
+					continue
+				}
+			}
+		case 206:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 231
+					continue
+				}
+			}
+		case 210:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 234
+					continue
+				}
+			}
+		case 211:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 235
+					continue
+				}
+			}
+		case 217:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/tests") {
+					n = 237
+					continue
+				}
+			}
+		case 218:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "co") {
+					n = 238
+					continue
+				}
+			case 'p':
+				if strings.HasPrefix(search, "p") {
+					n = 239
+					continue
+				}
+			}
+		case 225:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "comments") {
+					n = 243
+					continue
+				}
+			case 'e':
+				if strings.HasPrefix(search, "events") {
+					n = 244
+					continue
+				}
+			case 'l':
+				if strings.HasPrefix(search, "labels") {
+					n = 245
+					continue
+				}
+			}
+		case 229:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/labels") {
+					n = 248
+					continue
+				}
+			}
+		case 230:
+			switch search[0] {
+			case 'c':
+				if strings.HasPrefix(search, "comm") {
+					n = 249
+					continue
+				}
+			case 'f':
+				if strings.HasPrefix(search, "files") {
+					n = 250
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "merge") {
+					n = 251
+					continue
+				}
+			}
+		case 236:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/assets") {
+					n = 255
+					continue
+				}
+			}
+		case 238:
+			switch search[0] {
+			case 'd':
+				if strings.HasPrefix(search, "de_frequency") {
+					n = 258
+					continue
+				}
+			case 'm':
+				if strings.HasPrefix(search, "mmit_activity") {
+					n = 257
+					continue
+				}
+			case 'n':
+				if strings.HasPrefix(search, "ntributors") {
+					n = 256
+					continue
+				}
+			}
+		case 239:
+			switch search[0] {
+			case 'a':
+				if strings.HasPrefix(search, "articipation") {
+					n = 259
+					continue
+				}
+			case 'u':
+				if strings.HasPrefix(search, "unch_card") {
+					n = 260
+					continue
+				}
+			}
+		case 245:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/") {
+					n = 261
+					continue
+				}
+			}
+		case 249:
+			switch search[0] {
+			case 'e':
+				if strings.HasPrefix(search, "ents") {
+					n = 263
+					continue
+				}
+			case 'i':
+				if strings.HasPrefix(search, "its") {
+					n = 262
+					continue
+				}
+			}
+		case 254:
+			switch search[0] {
+			case '/':
+				if strings.HasPrefix(search, "/comments") {
+					n = 264
+					continue
+				}
+			}
+		}
+
+	matchParam:
+		if c := matcherParam[n]; c >= 0 {
+			n = c
+			i := strings.IndexByte(search, '/')
+			if i < 0 {
+				i = len(search)
+			}
+			values = append(values, search[:i])
+			search = search[i:]
+			if matcherLeaf[n] && search != "" {
+				return -1, values
+			}
+			continue
+		}
+
+	matchAny:
+		if c := matcherAny[n]; c >= 0 {
+			n = c
+			values = append(values, search)
+			search = ""
+			continue
+		}
+
+		// last ditch effort to match on a param or wildcard of an ancestor
+		tmpsearch = search
+		for matcherParent[n] >= 0 && matcherPrefix[n] != ":" {
+			tmpsearch = matcherPrefix[n] + tmpsearch
+			n = matcherParent[n]
+			if strings.HasSuffix(matcherPrefix[n], "/") {
+				if matcherParamLabel[n] {
+					search = tmpsearch
+					goto matchParam
+				}
+				if matcherAnyLabel[n] {
+					search = tmpsearch
+					goto matchAny
+				}
+			}
+		}
+		return -1, values
+	}
+}
@@ -0,0 +1,1563 @@
+// Code generated by vestigo-gen. DO NOT EDIT.
+
+package githubapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/husobee/vestigo"
+)
+
+var matcherTestRoutes = [][2]string{
+	{"GET", "/authorizations"},
+	{"GET", "/authorizations/:id"},
+	{"POST", "/authorizations"},
+	{"PUT", "/authorizations/clients/:client_id"},
+	{"PATCH", "/authorizations/:id"},
+	{"DELETE", "/authorizations/:id"},
+	{"GET", "/applications/:client_id/tokens/:access_token"},
+	{"DELETE", "/applications/:client_id/tokens"},
+	{"DELETE", "/applications/:client_id/tokens/:access_token"},
+	{"GET", "/events"},
+	{"GET", "/repos/:owner/:repo/events"},
+	{"GET", "/networks/:owner/:repo/events"},
+	{"GET", "/orgs/:org/events"},
+	{"GET", "/users/:user/received_events"},
+	{"GET", "/users/:user/received_events/public"},
+	{"GET", "/users/:user/events"},
+	{"GET", "/users/:user/events/public"},
+	{"GET", "/users/:user/events/orgs/:org"},
+	{"GET", "/feeds"},
+	{"GET", "/notifications"},
+	{"GET", "/repos/:owner/:repo/notifications"},
+	{"PUT", "/notifications"},
+	{"PUT", "/repos/:owner/:repo/notifications"},
+	{"GET", "/notifications/threads/:id"},
+	{"PATCH", "/notifications/threads/:id"},
+	{"GET", "/notifications/threads/:id/subscription"},
+	{"PUT", "/notifications/threads/:id/subscription"},
+	{"DELETE", "/notifications/threads/:id/subscription"},
+	{"GET", "/repos/:owner/:repo/stargazers"},
+	{"GET", "/users/:user/starred"},
+	{"GET", "/user/starred"},
+	{"GET", "/user/starred/:owner/:repo"},
+	{"PUT", "/user/starred/:owner/:repo"},
+	{"DELETE", "/user/starred/:owner/:repo"},
+	{"GET", "/repos/:owner/:repo/subscribers"},
+	{"GET", "/users/:user/subscriptions"},
+	{"GET", "/user/subscriptions"},
+	{"GET", "/repos/:owner/:repo/subscription"},
+	{"PUT", "/repos/:owner/:repo/subscription"},
+	{"DELETE", "/repos/:owner/:repo/subscription"},
+	{"GET", "/user/subscriptions/:owner/:repo"},
+	{"PUT", "/user/subscriptions/:owner/:repo"},
+	{"DELETE", "/user/subscriptions/:owner/:repo"},
+	{"GET", "/users/:user/gists"},
+	{"GET", "/gists"},
+	{"GET", "/gists/public"},
+	{"GET", "/gists/starred"},
+	{"GET", "/gists/:id"},
+	{"POST", "/gists"},
+	{"PATCH", "/gists/:id"},
+	{"PUT", "/gists/:id/star"},
+	{"DELETE", "/gists/:id/star"},
+	{"GET", "/gists/:id/star"},
+	{"POST", "/gists/:id/forks"},
+	{"DELETE", "/gists/:id"},
+	{"GET", "/repos/:owner/:repo/git/blobs/:sha"},
+	{"POST", "/repos/:owner/:repo/git/blobs"},
+	{"GET", "/repos/:owner/:repo/git/commits/:sha"},
+	{"POST", "/repos/:owner/:repo/git/commits"},
+	{"GET", "/repos/:owner/:repo/git/refs/*ref"},
+	{"GET", "/repos/:owner/:repo/git/refs"},
+	{"POST", "/repos/:owner/:repo/git/refs"},
+	{"PATCH", "/repos/:owner/:repo/git/refs/*ref"},
+	{"DELETE", "/repos/:owner/:repo/git/refs/*ref"},
+	{"GET", "/repos/:owner/:repo/git/tags/:sha"},
+	{"POST", "/repos/:owner/:repo/git/tags"},
+	{"GET", "/repos/:owner/:repo/git/trees/:sha"},
+	{"POST", "/repos/:owner/:repo/git/trees"},
+	{"GET", "/issues"},
+	{"GET", "/user/issues"},
+	{"GET", "/orgs/:org/issues"},
+	{"GET", "/repos/:owner/:repo/issues"},
+	{"GET", "/repos/:owner/:repo/issues/:number"},
+	{"POST", "/repos/:owner/:repo/issues"},
+	{"PATCH", "/repos/:owner/:repo/issues/:number"},
+	{"GET", "/repos/:owner/:repo/assignees"},
+	{"GET", "/repos/:owner/:repo/assignees/:assignee"},
+	{"GET", "/repos/:owner/:repo/issues/:number/comments"},
+	{"GET", "/repos/:owner/:repo/issues/comments"},
+	{"GET", "/repos/:owner/:repo/issues/comments/:id"},
+	{"POST", "/repos/:owner/:repo/issues/:number/comments"},
+	{"PATCH", "/repos/:owner/:repo/issues/comments/:id"},
+	{"DELETE", "/repos/:owner/:repo/issues/comments/:id"},
+	{"GET", "/repos/:owner/:repo/issues/:number/events"},
+	{"GET", "/repos/:owner/:repo/issues/events"},
+	{"GET", "/repos/:owner/:repo/issues/events/:id"},
+	{"GET", "/repos/:owner/:repo/labels"},
+	{"GET", "/repos/:owner/:repo/labels/:name"},
+	{"POST", "/repos/:owner/:repo/labels"},
+	{"PATCH", "/repos/:owner/:repo/labels/:name"},
+	{"DELETE", "/repos/:owner/:repo/labels/:name"},
+	{"GET", "/repos/:owner/:repo/issues/:number/labels"},
+	{"POST", "/repos/:owner/:repo/issues/:number/labels"},
+	{"DELETE", "/repos/:owner/:repo/issues/:number/labels/:name"},
+	{"PUT", "/repos/:owner/:repo/issues/:number/labels"},
+	{"DELETE", "/repos/:owner/:repo/issues/:number/labels"},
+	{"GET", "/repos/:owner/:repo/milestones/:number/labels"},
+	{"GET", "/repos/:owner/:repo/milestones"},
+	{"GET", "/repos/:owner/:repo/milestones/:number"},
+	{"POST", "/repos/:owner/:repo/milestones"},
+	{"PATCH", "/repos/:owner/:repo/milestones/:number"},
+	{"DELETE", "/repos/:owner/:repo/milestones/:number"},
+	{"GET", "/emojis"},
+	{"GET", "/gitignore/templates"},
+	{"GET", "/gitignore/templates/:name"},
+	{"POST", "/markdown"},
+	{"POST", "/markdown/raw"},
+	{"GET", "/meta"},
+	{"GET", "/rate_limit"},
+	{"GET", "/users/:user/orgs"},
+	{"GET", "/user/orgs"},
+	{"GET", "/orgs/:org"},
+	{"PATCH", "/orgs/:org"},
+	{"GET", "/orgs/:org/members"},
+	{"GET", "/orgs/:org/members/:user"},
+	{"DELETE", "/orgs/:org/members/:user"},
+	{"GET", "/orgs/:org/public_members"},
+	{"GET", "/orgs/:org/public_members/:user"},
+	{"PUT", "/orgs/:org/public_members/:user"},
+	{"DELETE", "/orgs/:org/public_members/:user"},
+	{"GET", "/orgs/:org/teams"},
+	{"GET", "/teams/:id"},
+	{"POST", "/orgs/:org/teams"},
+	{"PATCH", "/teams/:id"},
+	{"DELETE", "/teams/:id"},
+	{"GET", "/teams/:id/members"},
+	{"GET", "/teams/:id/members/:user"},
+	{"PUT", "/teams/:id/members/:user"},
+	{"DELETE", "/teams/:id/members/:user"},
+	{"GET", "/teams/:id/repos"},
+	{"GET", "/teams/:id/repos/:owner/:repo"},
+	{"PUT", "/teams/:id/repos/:owner/:repo"},
+	{"DELETE", "/teams/:id/repos/:owner/:repo"},
+	{"GET", "/user/teams"},
+	{"GET", "/repos/:owner/:repo/pulls"},
+	{"GET", "/repos/:owner/:repo/pulls/:number"},
+	{"POST", "/repos/:owner/:repo/pulls"},
+	{"PATCH", "/repos/:owner/:repo/pulls/:number"},
+	{"GET", "/repos/:owner/:repo/pulls/:number/commits"},
+	{"GET", "/repos/:owner/:repo/pulls/:number/files"},
+	{"GET", "/repos/:owner/:repo/pulls/:number/merge"},
+	{"PUT", "/repos/:owner/:repo/pulls/:number/merge"},
+	{"GET", "/repos/:owner/:repo/pulls/:number/comments"},
+	{"GET", "/repos/:owner/:repo/pulls/comments"},
+	{"GET", "/repos/:owner/:repo/pulls/comments/:number"},
+	{"PUT", "/repos/:owner/:repo/pulls/:number/comments"},
+	{"PATCH", "/repos/:owner/:repo/pulls/comments/:number"},
+	{"DELETE", "/repos/:owner/:repo/pulls/comments/:number"},
+	{"GET", "/user/repos"},
+	{"GET", "/users/:user/repos"},
+	{"GET", "/orgs/:org/repos"},
+	{"GET", "/repositories"},
+	{"POST", "/user/repos"},
+	{"POST", "/orgs/:org/repos"},
+	{"GET", "/repos/:owner/:repo"},
+	{"PATCH", "/repos/:owner/:repo"},
+	{"GET", "/repos/:owner/:repo/contributors"},
+	{"GET", "/repos/:owner/:repo/languages"},
+	{"GET", "/repos/:owner/:repo/teams"},
+	{"GET", "/repos/:owner/:repo/tags"},
+	{"GET", "/repos/:owner/:repo/branches"},
+	{"GET", "/repos/:owner/:repo/branches/:branch"},
+	{"DELETE", "/repos/:owner/:repo"},
+	{"GET", "/repos/:owner/:repo/collaborators"},
+	{"GET", "/repos/:owner/:repo/collaborators/:user"},
+	{"PUT", "/repos/:owner/:repo/collaborators/:user"},
+	{"DELETE", "/repos/:owner/:repo/collaborators/:user"},
+	{"GET", "/repos/:owner/:repo/comments"},
+	{"GET", "/repos/:owner/:repo/commits/:sha/comments"},
+	{"POST", "/repos/:owner/:repo/commits/:sha/comments"},
+	{"GET", "/repos/:owner/:repo/comments/:id"},
+	{"PATCH", "/repos/:owner/:repo/comments/:id"},
+	{"DELETE", "/repos/:owner/:repo/comments/:id"},
+	{"GET", "/repos/:owner/:repo/commits"},
+	{"GET", "/repos/:owner/:repo/commits/:sha"},
+	{"GET", "/repos/:owner/:repo/readme"},
+	{"GET", "/repos/:owner/:repo/contents/*path"},
+	{"PUT", "/repos/:owner/:repo/contents/*path"},
+	{"DELETE", "/repos/:owner/:repo/contents/*path"},
+	{"GET", "/repos/:owner/:repo/:archive_format/:ref"},
+	{"GET", "/repos/:owner/:repo/keys"},
+	{"GET", "/repos/:owner/:repo/keys/:id"},
+	{"POST", "/repos/:owner/:repo/keys"},
+	{"PATCH", "/repos/:owner/:repo/keys/:id"},
+	{"DELETE", "/repos/:owner/:repo/keys/:id"},
+	{"GET", "/repos/:owner/:repo/downloads"},
+	{"GET", "/repos/:owner/:repo/downloads/:id"},
+	{"DELETE", "/repos/:owner/:repo/downloads/:id"},
+	{"GET", "/repos/:owner/:repo/forks"},
+	{"POST", "/repos/:owner/:repo/forks"},
+	{"GET", "/repos/:owner/:repo/hooks"},
+	{"GET", "/repos/:owner/:repo/hooks/:id"},
+	{"POST", "/repos/:owner/:repo/hooks"},
+	{"PATCH", "/repos/:owner/:repo/hooks/:id"},
+	{"POST", "/repos/:owner/:repo/hooks/:id/tests"},
+	{"DELETE", "/repos/:owner/:repo/hooks/:id"},
+	{"POST", "/repos/:owner/:repo/merges"},
+	{"GET", "/repos/:owner/:repo/releases"},
+	{"GET", "/repos/:owner/:repo/releases/:id"},
+	{"POST", "/repos/:owner/:repo/releases"},
+	{"PATCH", "/repos/:owner/:repo/releases/:id"},
+	{"DELETE", "/repos/:owner/:repo/releases/:id"},
+	{"GET", "/repos/:owner/:repo/releases/:id/assets"},
+	{"GET", "/repos/:owner/:repo/stats/contributors"},
+	{"GET", "/repos/:owner/:repo/stats/commit_activity"},
+	{"GET", "/repos/:owner/:repo/stats/code_frequency"},
+	{"GET", "/repos/:owner/:repo/stats/participation"},
+	{"GET", "/repos/:owner/:repo/stats/punch_card"},
+	{"GET", "/repos/:owner/:repo/statuses/:ref"},
+	{"POST", "/repos/:owner/:repo/statuses/:ref"},
+	{"GET", "/search/repositories"},
+	{"GET", "/search/code"},
+	{"GET", "/search/issues"},
+	{"GET", "/search/users"},
+	{"GET", "/legacy/issues/search/:owner/:repository/:state/:keyword"},
+	{"GET", "/legacy/repos/search/:keyword"},
+	{"GET", "/legacy/user/search/:keyword"},
+	{"GET", "/legacy/user/email/:email"},
+	{"GET", "/users/:user"},
+	{"GET", "/user"},
+	{"PATCH", "/user"},
+	{"GET", "/users"},
+	{"GET", "/user/emails"},
+	{"POST", "/user/emails"},
+	{"DELETE", "/user/emails"},
+	{"GET", "/users/:user/followers"},
+	{"GET", "/user/followers"},
+	{"GET", "/users/:user/following"},
+	{"GET", "/user/following"},
+	{"GET", "/user/following/:user"},
+	{"GET", "/users/:user/following/:target_user"},
+	{"PUT", "/user/following/:user"},
+	{"DELETE", "/user/following/:user"},
+	{"GET", "/users/:user/keys"},
+	{"GET", "/user/keys"},
+	{"GET", "/user/keys/:id"},
+	{"POST", "/user/keys"},
+	{"PATCH", "/user/keys/:id"},
+	{"DELETE", "/user/keys/:id"},
+}
+
+var matcherTestPaths = []string{
+	"/",
+	"/applications",
+	"/applications/",
+	"/applications//",
+	"/applications//tokens",
+	"/applications//tokens/",
+	"/applications//tokens//",
+	"/applications//tokens//extra",
+	"/applications//tokens/extra",
+	"/applications/:client_id",
+	"/applications/:client_id/",
+	"/applications/:client_id/tokens",
+	"/applications/:client_id/tokens/",
+	"/applications/:client_id/tokens/:access_token",
+	"/applications/:client_id/tokens/:access_token/",
+	"/applications/:client_id/tokens/:access_token/extra",
+	"/applications/:client_id/tokens/extra",
+	"/applications/x",
+	"/applications/x/",
+	"/applications/x/tokens",
+	"/applications/x/tokens/",
+	"/applications/x/tokens/extra",
+	"/applications/x/tokens/x",
+	"/applications/x/tokens/x/",
+	"/applications/x/tokens/x/extra",
+	"/authorizations",
+	"/authorizations/",
+	"/authorizations//",
+	"/authorizations//extra",
+	"/authorizations/:id",
+	"/authorizations/:id/",
+	"/authorizations/:id/extra",
+	"/authorizations/clients",
+	"/authorizations/clients/",
+	"/authorizations/clients//",
+	"/authorizations/clients//extra",
+	"/authorizations/clients/:client_id",
+	"/authorizations/clients/:client_id/",
+	"/authorizations/clients/:client_id/extra",
+	"/authorizations/clients/x",
+	"/authorizations/clients/x/",
+	"/authorizations/clients/x/extra",
+	"/authorizations/extra",
+	"/authorizations/x",
+	"/authorizations/x/",
+	"/authorizations/x/extra",
+	"/emojis",
+	"/emojis/",
+	"/emojis/extra",
+	"/events",
+	"/events/",
+	"/events/extra",
+	"/feeds",
+	"/feeds/",
+	"/feeds/extra",
+	"/gists",
+	"/gists/",
+	"/gists//",
+	"/gists//extra",
+	"/gists//forks",
+	"/gists//forks/",
+	"/gists//forks/extra",
+	"/gists//star",
+	"/gists//star/",
+	"/gists//star/extra",
+	"/gists/:id",
+	"/gists/:id/",
+	"/gists/:id/extra",
+	"/gists/:id/forks",
+	"/gists/:id/forks/",
+	"/gists/:id/forks/extra",
+	"/gists/:id/star",
+	"/gists/:id/star/",
+	"/gists/:id/star/extra",
+	"/gists/extra",
+	"/gists/public",
+	"/gists/public/",
+	"/gists/public/extra",
+	"/gists/starred",
+	"/gists/starred/",
+	"/gists/starred/extra",
+	"/gists/x",
+	"/gists/x/",
+	"/gists/x/extra",
+	"/gists/x/forks",
+	"/gists/x/forks/",
+	"/gists/x/forks/extra",
+	"/gists/x/star",
+	"/gists/x/star/",
+	"/gists/x/star/extra",
+	"/gitignore",
+	"/gitignore/",
+	"/gitignore/templates",
+	"/gitignore/templates/",
+	"/gitignore/templates//",
+	"/gitignore/templates//extra",
+	"/gitignore/templates/:name",
+	"/gitignore/templates/:name/",
+	"/gitignore/templates/:name/extra",
+	"/gitignore/templates/extra",
+	"/gitignore/templates/x",
+	"/gitignore/templates/x/",
+	"/gitignore/templates/x/extra",
+	"/issues",
+	"/issues/",
+	"/issues/extra",
+	"/legacy",
+	"/legacy/",
+	"/legacy/issues",
+	"/legacy/issues/",
+	"/legacy/issues/search",
+	"/legacy/issues/search/",
+	"/legacy/issues/search//",
+	"/legacy/issues/search///",
+	"/legacy/issues/search////",
+	"/legacy/issues/search/////",
+	"/legacy/issues/search/////extra",
+	"/legacy/issues/search/:owner",
+	"/legacy/issues/search/:owner/",
+	"/legacy/issues/search/:owner/:repository",
+	"/legacy/issues/search/:owner/:repository/",
+	"/legacy/issues/search/:owner/:repository/:state",
+	"/legacy/issues/search/:owner/:repository/:state/",
+	"/legacy/issues/search/:owner/:repository/:state/:keyword",
+	"/legacy/issues/search/:owner/:repository/:state/:keyword/",
+	"/legacy/issues/search/:owner/:repository/:state/:keyword/extra",
+	"/legacy/issues/search/x",
+	"/legacy/issues/search/x/",
+	"/legacy/issues/search/x/x",
+	"/legacy/issues/search/x/x/",
+	"/legacy/issues/search/x/x/x",
+	"/legacy/issues/search/x/x/x/",
+	"/legacy/issues/search/x/x/x/x",
+	"/legacy/issues/search/x/x/x/x/",
+	"/legacy/issues/search/x/x/x/x/extra",
+	"/legacy/repos",
+	"/legacy/repos/",
+	"/legacy/repos/search",
+	"/legacy/repos/search/",
+	"/legacy/repos/search//",
+	"/legacy/repos/search//extra",
+	"/legacy/repos/search/:keyword",
+	"/legacy/repos/search/:keyword/",
+	"/legacy/repos/search/:keyword/extra",
+	"/legacy/repos/search/x",
+	"/legacy/repos/search/x/",
+	"/legacy/repos/search/x/extra",
+	"/legacy/user",
+	"/legacy/user/",
+	"/legacy/user/email",
+	"/legacy/user/email/",
+	"/legacy/user/email//",
+	"/legacy/user/email//extra",
+	"/legacy/user/email/:email",
+	"/legacy/user/email/:email/",
+	"/legacy/user/email/:email/extra",
+	"/legacy/user/email/x",
+	"/legacy/user/email/x/",
+	"/legacy/user/email/x/extra",
+	"/legacy/user/search",
+	"/legacy/user/search/",
+	"/legacy/user/search//",
+	"/legacy/user/search//extra",
+	"/legacy/user/search/:keyword",
+	"/legacy/user/search/:keyword/",
+	"/legacy/user/search/:keyword/extra",
+	"/legacy/user/search/x",
+	"/legacy/user/search/x/",
+	"/legacy/user/search/x/extra",
+	"/markdown",
+	"/markdown/",
+	"/markdown/extra",
+	"/markdown/raw",
+	"/markdown/raw/",
+	"/markdown/raw/extra",
+	"/meta",
+	"/meta/",
+	"/meta/extra",
+	"/networks",
+	"/networks/",
+	"/networks//",
+	"/networks///",
+	"/networks///events",
+	"/networks///events/",
+	"/networks///events/extra",
+	"/networks/:owner",
+	"/networks/:owner/",
+	"/networks/:owner/:repo",
+	"/networks/:owner/:repo/",
+	"/networks/:owner/:repo/events",
+	"/networks/:owner/:repo/events/",
+	"/networks/:owner/:repo/events/extra",
+	"/networks/x",
+	"/networks/x/",
+	"/networks/x/x",
+	"/networks/x/x/",
+	"/networks/x/x/events",
+	"/networks/x/x/events/",
+	"/networks/x/x/events/extra",
+	"/notifications",
+	"/notifications/",
+	"/notifications/extra",
+	"/notifications/threads",
+	"/notifications/threads/",
+	"/notifications/threads//",
+	"/notifications/threads//extra",
+	"/notifications/threads//subscription",
+	"/notifications/threads//subscription/",
+	"/notifications/threads//subscription/extra",
+	"/notifications/threads/:id",
+	"/notifications/threads/:id/",
+	"/notifications/threads/:id/extra",
+	"/notifications/threads/:id/subscription",
+	"/notifications/threads/:id/subscription/",
+	"/notifications/threads/:id/subscription/extra",
+	"/notifications/threads/x",
+	"/notifications/threads/x/",
+	"/notifications/threads/x/extra",
+	"/notifications/threads/x/subscription",
+	"/notifications/threads/x/subscription/",
+	"/notifications/threads/x/subscription/extra",
+	"/orgs",
+	"/orgs/",
+	"/orgs//",
+	"/orgs//events",
+	"/orgs//events/",
+	"/orgs//events/extra",
+	"/orgs//extra",
+	"/orgs//issues",
+	"/orgs//issues/",
+	"/orgs//issues/extra",
+	"/orgs//members",
+	"/orgs//members/",
+	"/orgs//members//",
+	"/orgs//members//extra",
+	"/orgs//members/extra",
+	"/orgs//public_members",
+	"/orgs//public_members/",
+	"/orgs//public_members//",
+	"/orgs//public_members//extra",
+	"/orgs//public_members/extra",
+	"/orgs//repos",
+	"/orgs//repos/",
+	"/orgs//repos/extra",
+	"/orgs//teams",
+	"/orgs//teams/",
+	"/orgs//teams/extra",
+	"/orgs/:org",
+	"/orgs/:org/",
+	"/orgs/:org/events",
+	"/orgs/:org/events/",
+	"/orgs/:org/events/extra",
+	"/orgs/:org/extra",
+	"/orgs/:org/issues",
+	"/orgs/:org/issues/",
+	"/orgs/:org/issues/extra",
+	"/orgs/:org/members",
+	"/orgs/:org/members/",
+	"/orgs/:org/members/:user",
+	"/orgs/:org/members/:user/",
+	"/orgs/:org/members/:user/extra",
+	"/orgs/:org/members/extra",
+	"/orgs/:org/public_members",
+	"/orgs/:org/public_members/",
+	"/orgs/:org/public_members/:user",
+	"/orgs/:org/public_members/:user/",
+	"/orgs/:org/public_members/:user/extra",
+	"/orgs/:org/public_members/extra",
+	"/orgs/:org/repos",
+	"/orgs/:org/repos/",
+	"/orgs/:org/repos/extra",
+	"/orgs/:org/teams",
+	"/orgs/:org/teams/",
+	"/orgs/:org/teams/extra",
+	"/orgs/x",
+	"/orgs/x/",
+	"/orgs/x/events",
+	"/orgs/x/events/",
+	"/orgs/x/events/extra",
+	"/orgs/x/extra",
+	"/orgs/x/issues",
+	"/orgs/x/issues/",
+	"/orgs/x/issues/extra",
+	"/orgs/x/members",
+	"/orgs/x/members/",
+	"/orgs/x/members/extra",
+	"/orgs/x/members/x",
+	"/orgs/x/members/x/",
+	"/orgs/x/members/x/extra",
+	"/orgs/x/public_members",
+	"/orgs/x/public_members/",
+	"/orgs/x/public_members/extra",
+	"/orgs/x/public_members/x",
+	"/orgs/x/public_members/x/",
+	"/orgs/x/public_members/x/extra",
+	"/orgs/x/repos",
+	"/orgs/x/repos/",
+	"/orgs/x/repos/extra",
+	"/orgs/x/teams",
+	"/orgs/x/teams/",
+	"/orgs/x/teams/extra",
+	"/rate_limit",
+	"/rate_limit/",
+	"/rate_limit/extra",
+	"/repos",
+	"/repos/",
+	"/repos//",
+	"/repos///",
+	"/repos////",
+	"/repos/////",
+	"/repos/////extra",
+	"/repos///assignees",
+	"/repos///assignees/",
+	"/repos///assignees//",
+	"/repos///assignees//extra",
+	"/repos///assignees/extra",
+	"/repos///branches",
+	"/repos///branches/",
+	"/repos///branches//",
+	"/repos///branches//extra",
+	"/repos///branches/extra",
+	"/repos///collaborators",
+	"/repos///collaborators/",
+	"/repos///collaborators//",
+	"/repos///collaborators//extra",
+	"/repos///collaborators/extra",
+	"/repos///comments",
+	"/repos///comments/",
+	"/repos///comments//",
+	"/repos///comments//extra",
+	"/repos///comments/extra",
+	"/repos///commits",
+	"/repos///commits/",
+	"/repos///commits//",
+	"/repos///commits//comments",
+	"/repos///commits//comments/",
+	"/repos///commits//comments/extra",
+	"/repos///commits//extra",
+	"/repos///commits/extra",
+	"/repos///contents",
+	"/repos///contents/",
+	"/repos///contents//",
+	"/repos///contents//extra",
+	"/repos///contributors",
+	"/repos///contributors/",
+	"/repos///contributors/extra",
+	"/repos///downloads",
+	"/repos///downloads/",
+	"/repos///downloads//",
+	"/repos///downloads//extra",
+	"/repos///downloads/extra",
+	"/repos///events",
+	"/repos///events/",
+	"/repos///events/extra",
+	"/repos///extra",
+	"/repos///forks",
+	"/repos///forks/",
+	"/repos///forks/extra",
+	"/repos///git",
+	"/repos///git/",
+	"/repos///git/blobs",
+	"/repos///git/blobs/",
+	"/repos///git/blobs//",
+	"/repos///git/blobs//extra",
+	"/repos///git/blobs/extra",
+	"/repos///git/commits",
+	"/repos///git/commits/",
+	"/repos///git/commits//",
+	"/repos///git/commits//extra",
+	"/repos///git/commits/extra",
+	"/repos///git/refs",
+	"/repos///git/refs/",
+	"/repos///git/refs//",
+	"/repos///git/refs//extra",
+	"/repos///git/refs/extra",
+	"/repos///git/tags",
+	"/repos///git/tags/",
+	"/repos///git/tags//",
+	"/repos///git/tags//extra",
+	"/repos///git/tags/extra",
+	"/repos///git/trees",
+	"/repos///git/trees/",
+	"/repos///git/trees//",
+	"/repos///git/trees//extra",
+	"/repos///git/trees/extra",
+	"/repos///hooks",
+	"/repos///hooks/",
+	"/repos///hooks//",
+	"/repos///hooks//extra",
+	"/repos///hooks//tests",
+	"/repos///hooks//tests/",
+	"/repos///hooks//tests/extra",
+	"/repos///hooks/extra",
+	"/repos///issues",
+	"/repos///issues/",
+	"/repos///issues//",
+	"/repos///issues//comments",
+	"/repos///issues//comments/",
+	"/repos///issues//comments/extra",
+	"/repos///issues//events",
+	"/repos///issues//events/",
+	"/repos///issues//events/extra",
+	"/repos///issues//extra",
+	"/repos///issues//labels",
+	"/repos///issues//labels/",
+	"/repos///issues//labels//",
+	"/repos///issues//labels//extra",
+	"/repos///issues//labels/extra",
+	"/repos///issues/comments",
+	"/repos///issues/comments/",
+	"/repos///issues/comments//",
+	"/repos///issues/comments//extra",
+	"/repos///issues/comments/extra",
+	"/repos///issues/events",
+	"/repos///issues/events/",
+	"/repos///issues/events//",
+	"/repos///issues/events//extra",
+	"/repos///issues/events/extra",
+	"/repos///issues/extra",
+	"/repos///keys",
+	"/repos///keys/",
+	"/repos///keys//",
+	"/repos///keys//extra",
+	"/repos///keys/extra",
+	"/repos///labels",
+	"/repos///labels/",
+	"/repos///labels//",
+	"/repos///labels//extra",
+	"/repos///labels/extra",
+	"/repos///languages",
+	"/repos///languages/",
+	"/repos///languages/extra",
+	"/repos///merges",
+	"/repos///merges/",
+	"/repos///merges/extra",
+	"/repos///milestones",
+	"/repos///milestones/",
+	"/repos///milestones//",
+	"/repos///milestones//extra",
+	"/repos///milestones//labels",
+	"/repos///milestones//labels/",
+	"/repos///milestones//labels/extra",
+	"/repos///milestones/extra",
+	"/repos///notifications",
+	"/repos///notifications/",
+	"/repos///notifications/extra",
+	"/repos///pulls",
+	"/repos///pulls/",
+	"/repos///pulls//",
+	"/repos///pulls//comments",
+	"/repos///pulls//comments/",
+	"/repos///pulls//comments/extra",
+	"/repos///pulls//commits",
+	"/repos///pulls//commits/",
+	"/repos///pulls//commits/extra",
+	"/repos///pulls//extra",
+	"/repos///pulls//files",
+	"/repos///pulls//files/",
+	"/repos///pulls//files/extra",
+	"/repos///pulls//merge",
+	"/repos///pulls//merge/",
+	"/repos///pulls//merge/extra",
+	"/repos///pulls/comments",
+	"/repos///pulls/comments/",
+	"/repos///pulls/comments//",
+	"/repos///pulls/comments//extra",
+	"/repos///pulls/comments/extra",
+	"/repos///pulls/extra",
+	"/repos///readme",
+	"/repos///readme/",
+	"/repos///readme/extra",
+	"/repos///releases",
+	"/repos///releases/",
+	"/repos///releases//",
+	"/repos///releases//assets",
+	"/repos///releases//assets/",
+	"/repos///releases//assets/extra",
+	"/repos///releases//extra",
+	"/repos///releases/extra",
+	"/repos///stargazers",
+	"/repos///stargazers/",
+	"/repos///stargazers/extra",
+	"/repos///stats",
+	"/repos///stats/",
+	"/repos///stats/code_frequency",
+	"/repos///stats/code_frequency/",
+	"/repos///stats/code_frequency/extra",
+	"/repos///stats/commit_activity",
+	"/repos///stats/commit_activity/",
+	"/repos///stats/commit_activity/extra",
+	"/repos///stats/contributors",
+	"/repos///stats/contributors/",
+	"/repos///stats/contributors/extra",
+	"/repos///stats/participation",
+	"/repos///stats/participation/",
+	"/repos///stats/participation/extra",
+	"/repos///stats/punch_card",
+	"/repos///stats/punch_card/",
+	"/repos///stats/punch_card/extra",
+	"/repos///statuses",
+	"/repos///statuses/",
+	"/repos///statuses//",
+	"/repos///statuses//extra",
+	"/repos///subscribers",
+	"/repos///subscribers/",
+	"/repos///subscribers/extra",
+	"/repos///subscription",
+	"/repos///subscription/",
+	"/repos///subscription/extra",
+	"/repos///tags",
+	"/repos///tags/",
+	"/repos///tags/extra",
+	"/repos///teams",
+	"/repos///teams/",
+	"/repos///teams/extra",
+	"/repos/:owner",
+	"/repos/:owner/",
+	"/repos/:owner/:repo",
+	"/repos/:owner/:repo/",
+	"/repos/:owner/:repo/:archive_format",
+	"/repos/:owner/:repo/:archive_format/",
+	"/repos/:owner/:repo/:archive_format/:ref",
+	"/repos/:owner/:repo/:archive_format/:ref/",
+	"/repos/:owner/:repo/:archive_format/:ref/extra",
+	"/repos/:owner/:repo/assignees",
+	"/repos/:owner/:repo/assignees/",
+	"/repos/:owner/:repo/assignees/:assignee",
+	"/repos/:owner/:repo/assignees/:assignee/",
+	"/repos/:owner/:repo/assignees/:assignee/extra",
+	"/repos/:owner/:repo/assignees/extra",
+	"/repos/:owner/:repo/branches",
+	"/repos/:owner/:repo/branches/",
+	"/repos/:owner/:repo/branches/:branch",
+	"/repos/:owner/:repo/branches/:branch/",
+	"/repos/:owner/:repo/branches/:branch/extra",
+	"/repos/:owner/:repo/branches/extra",
+	"/repos/:owner/:repo/collaborators",
+	"/repos/:owner/:repo/collaborators/",
+	"/repos/:owner/:repo/collaborators/:user",
+	"/repos/:owner/:repo/collaborators/:user/",
+	"/repos/:owner/:repo/collaborators/:user/extra",
+	"/repos/:owner/:repo/collaborators/extra",
+	"/repos/:owner/:repo/comments",
+	"/repos/:owner/:repo/comments/",
+	"/repos/:owner/:repo/comments/:id",
+	"/repos/:owner/:repo/comments/:id/",
+	"/repos/:owner/:repo/comments/:id/extra",
+	"/repos/:owner/:repo/comments/extra",
+	"/repos/:owner/:repo/commits",
+	"/repos/:owner/:repo/commits/",
+	"/repos/:owner/:repo/commits/:sha",
+	"/repos/:owner/:repo/commits/:sha/",
+	"/repos/:owner/:repo/commits/:sha/comments",
+	"/repos/:owner/:repo/commits/:sha/comments/",
+	"/repos/:owner/:repo/commits/:sha/comments/extra",
+	"/repos/:owner/:repo/commits/:sha/extra",
+	"/repos/:owner/:repo/commits/extra",
+	"/repos/:owner/:repo/contents",
+	"/repos/:owner/:repo/contents/",
+	"/repos/:owner/:repo/contents/*path",
+	"/repos/:owner/:repo/contents/*path/",
+	"/repos/:owner/:repo/contents/*path/extra",
+	"/repos/:owner/:repo/contributors",
+	"/repos/:owner/:repo/contributors/",
+	"/repos/:owner/:repo/contributors/extra",
+	"/repos/:owner/:repo/downloads",
+	"/repos/:owner/:repo/downloads/",
+	"/repos/:owner/:repo/downloads/:id",
+	"/repos/:owner/:repo/downloads/:id/",
+	"/repos/:owner/:repo/downloads/:id/extra",
+	"/repos/:owner/:repo/downloads/extra",
+	"/repos/:owner/:repo/events",
+	"/repos/:owner/:repo/events/",
+	"/repos/:owner/:repo/events/extra",
+	"/repos/:owner/:repo/extra",
+	"/repos/:owner/:repo/forks",
+	"/repos/:owner/:repo/forks/",
+	"/repos/:owner/:repo/forks/extra",
+	"/repos/:owner/:repo/git",
+	"/repos/:owner/:repo/git/",
+	"/repos/:owner/:repo/git/blobs",
+	"/repos/:owner/:repo/git/blobs/",
+	"/repos/:owner/:repo/git/blobs/:sha",
+	"/repos/:owner/:repo/git/blobs/:sha/",
+	"/repos/:owner/:repo/git/blobs/:sha/extra",
+	"/repos/:owner/:repo/git/blobs/extra",
+	"/repos/:owner/:repo/git/commits",
+	"/repos/:owner/:repo/git/commits/",
+	"/repos/:owner/:repo/git/commits/:sha",
+	"/repos/:owner/:repo/git/commits/:sha/",
+	"/repos/:owner/:repo/git/commits/:sha/extra",
+	"/repos/:owner/:repo/git/commits/extra",
+	"/repos/:owner/:repo/git/refs",
+	"/repos/:owner/:repo/git/refs/",
+	"/repos/:owner/:repo/git/refs/*ref",
+	"/repos/:owner/:repo/git/refs/*ref/",
+	"/repos/:owner/:repo/git/refs/*ref/extra",
+	"/repos/:owner/:repo/git/refs/extra",
+	"/repos/:owner/:repo/git/tags",
+	"/repos/:owner/:repo/git/tags/",
+	"/repos/:owner/:repo/git/tags/:sha",
+	"/repos/:owner/:repo/git/tags/:sha/",
+	"/repos/:owner/:repo/git/tags/:sha/extra",
+	"/repos/:owner/:repo/git/tags/extra",
+	"/repos/:owner/:repo/git/trees",
+	"/repos/:owner/:repo/git/trees/",
+	"/repos/:owner/:repo/git/trees/:sha",
+	"/repos/:owner/:repo/git/trees/:sha/",
+	"/repos/:owner/:repo/git/trees/:sha/extra",
+	"/repos/:owner/:repo/git/trees/extra",
+	"/repos/:owner/:repo/hooks",
+	"/repos/:owner/:repo/hooks/",
+	"/repos/:owner/:repo/hooks/:id",
+	"/repos/:owner/:repo/hooks/:id/",
+	"/repos/:owner/:repo/hooks/:id/extra",
+	"/repos/:owner/:repo/hooks/:id/tests",
+	"/repos/:owner/:repo/hooks/:id/tests/",
+	"/repos/:owner/:repo/hooks/:id/tests/extra",
+	"/repos/:owner/:repo/hooks/extra",
+	"/repos/:owner/:repo/issues",
+	"/repos/:owner/:repo/issues/",
+	"/repos/:owner/:repo/issues/:number",
+	"/repos/:owner/:repo/issues/:number/",
+	"/repos/:owner/:repo/issues/:number/comments",
+	"/repos/:owner/:repo/issues/:number/comments/",
+	"/repos/:owner/:repo/issues/:number/comments/extra",
+	"/repos/:owner/:repo/issues/:number/events",
+	"/repos/:owner/:repo/issues/:number/events/",
+	"/repos/:owner/:repo/issues/:number/events/extra",
+	"/repos/:owner/:repo/issues/:number/extra",
+	"/repos/:owner/:repo/issues/:number/labels",
+	"/repos/:owner/:repo/issues/:number/labels/",
+	"/repos/:owner/:repo/issues/:number/labels/:name",
+	"/repos/:owner/:repo/issues/:number/labels/:name/",
+	"/repos/:owner/:repo/issues/:number/labels/:name/extra",
+	"/repos/:owner/:repo/issues/:number/labels/extra",
+	"/repos/:owner/:repo/issues/comments",
+	"/repos/:owner/:repo/issues/comments/",
+	"/repos/:owner/:repo/issues/comments/:id",
+	"/repos/:owner/:repo/issues/comments/:id/",
+	"/repos/:owner/:repo/issues/comments/:id/extra",
+	"/repos/:owner/:repo/issues/comments/extra",
+	"/repos/:owner/:repo/issues/events",
+	"/repos/:owner/:repo/issues/events/",
+	"/repos/:owner/:repo/issues/events/:id",
+	"/repos/:owner/:repo/issues/events/:id/",
+	"/repos/:owner/:repo/issues/events/:id/extra",
+	"/repos/:owner/:repo/issues/events/extra",
+	"/repos/:owner/:repo/issues/extra",
+	"/repos/:owner/:repo/keys",
+	"/repos/:owner/:repo/keys/",
+	"/repos/:owner/:repo/keys/:id",
+	"/repos/:owner/:repo/keys/:id/",
+	"/repos/:owner/:repo/keys/:id/extra",
+	"/repos/:owner/:repo/keys/extra",
+	"/repos/:owner/:repo/labels",
+	"/repos/:owner/:repo/labels/",
+	"/repos/:owner/:repo/labels/:name",
+	"/repos/:owner/:repo/labels/:name/",
+	"/repos/:owner/:repo/labels/:name/extra",
+	"/repos/:owner/:repo/labels/extra",
+	"/repos/:owner/:repo/languages",
+	"/repos/:owner/:repo/languages/",
+	"/repos/:owner/:repo/languages/extra",
+	"/repos/:owner/:repo/merges",
+	"/repos/:owner/:repo/merges/",
+	"/repos/:owner/:repo/merges/extra",
+	"/repos/:owner/:repo/milestones",
+	"/repos/:owner/:repo/milestones/",
+	"/repos/:owner/:repo/milestones/:number",
+	"/repos/:owner/:repo/milestones/:number/",
+	"/repos/:owner/:repo/milestones/:number/extra",
+	"/repos/:owner/:repo/milestones/:number/labels",
+	"/repos/:owner/:repo/milestones/:number/labels/",
+	"/repos/:owner/:repo/milestones/:number/labels/extra",
+	"/repos/:owner/:repo/milestones/extra",
+	"/repos/:owner/:repo/notifications",
+	"/repos/:owner/:repo/notifications/",
+	"/repos/:owner/:repo/notifications/extra",
+	"/repos/:owner/:repo/pulls",
+	"/repos/:owner/:repo/pulls/",
+	"/repos/:owner/:repo/pulls/:number",
+	"/repos/:owner/:repo/pulls/:number/",
+	"/repos/:owner/:repo/pulls/:number/comments",
+	"/repos/:owner/:repo/pulls/:number/comments/",
+	"/repos/:owner/:repo/pulls/:number/comments/extra",
+	"/repos/:owner/:repo/pulls/:number/commits",
+	"/repos/:owner/:repo/pulls/:number/commits/",
+	"/repos/:owner/:repo/pulls/:number/commits/extra",
+	"/repos/:owner/:repo/pulls/:number/extra",
+	"/repos/:owner/:repo/pulls/:number/files",
+	"/repos/:owner/:repo/pulls/:number/files/",
+	"/repos/:owner/:repo/pulls/:number/files/extra",
+	"/repos/:owner/:repo/pulls/:number/merge",
+	"/repos/:owner/:repo/pulls/:number/merge/",
+	"/repos/:owner/:repo/pulls/:number/merge/extra",
+	"/repos/:owner/:repo/pulls/comments",
+	"/repos/:owner/:repo/pulls/comments/",
+	"/repos/:owner/:repo/pulls/comments/:number",
+	"/repos/:owner/:repo/pulls/comments/:number/",
+	"/repos/:owner/:repo/pulls/comments/:number/extra",
+	"/repos/:owner/:repo/pulls/comments/extra",
+	"/repos/:owner/:repo/pulls/extra",
+	"/repos/:owner/:repo/readme",
+	"/repos/:owner/:repo/readme/",
+	"/repos/:owner/:repo/readme/extra",
+	"/repos/:owner/:repo/releases",
+	"/repos/:owner/:repo/releases/",
+	"/repos/:owner/:repo/releases/:id",
+	"/repos/:owner/:repo/releases/:id/",
+	"/repos/:owner/:repo/releases/:id/assets",
+	"/repos/:owner/:repo/releases/:id/assets/",
+	"/repos/:owner/:repo/releases/:id/assets/extra",
+	"/repos/:owner/:repo/releases/:id/extra",
+	"/repos/:owner/:repo/releases/extra",
+	"/repos/:owner/:repo/stargazers",
+	"/repos/:owner/:repo/stargazers/",
+	"/repos/:owner/:repo/stargazers/extra",
+	"/repos/:owner/:repo/stats",
+	"/repos/:owner/:repo/stats/",
+	"/repos/:owner/:repo/stats/code_frequency",
+	"/repos/:owner/:repo/stats/code_frequency/",
+	"/repos/:owner/:repo/stats/code_frequency/extra",
+	"/repos/:owner/:repo/stats/commit_activity",
+	"/repos/:owner/:repo/stats/commit_activity/",
+	"/repos/:owner/:repo/stats/commit_activity/extra",
+	"/repos/:owner/:repo/stats/contributors",
+	"/repos/:owner/:repo/stats/contributors/",
+	"/repos/:owner/:repo/stats/contributors/extra",
+	"/repos/:owner/:repo/stats/participation",
+	"/repos/:owner/:repo/stats/participation/",
+	"/repos/:owner/:repo/stats/participation/extra",
+	"/repos/:owner/:repo/stats/punch_card",
+	"/repos/:owner/:repo/stats/punch_card/",
+	"/repos/:owner/:repo/stats/punch_card/extra",
+	"/repos/:owner/:repo/statuses",
+	"/repos/:owner/:repo/statuses/",
+	"/repos/:owner/:repo/statuses/:ref",
+	"/repos/:owner/:repo/statuses/:ref/",
+	"/repos/:owner/:repo/statuses/:ref/extra",
+	"/repos/:owner/:repo/subscribers",
+	"/repos/:owner/:repo/subscribers/",
+	"/repos/:owner/:repo/subscribers/extra",
+	"/repos/:owner/:repo/subscription",
+	"/repos/:owner/:repo/subscription/",
+	"/repos/:owner/:repo/subscription/extra",
+	"/repos/:owner/:repo/tags",
+	"/repos/:owner/:repo/tags/",
+	"/repos/:owner/:repo/tags/extra",
+	"/repos/:owner/:repo/teams",
+	"/repos/:owner/:repo/teams/",
+	"/repos/:owner/:repo/teams/extra",
+	"/repos/x",
+	"/repos/x/",
+	"/repos/x/x",
+	"/repos/x/x/",
+	"/repos/x/x/assignees",
+	"/repos/x/x/assignees/",
+	"/repos/x/x/assignees/extra",
+	"/repos/x/x/assignees/x",
+	"/repos/x/x/assignees/x/",
+	"/repos/x/x/assignees/x/extra",
+	"/repos/x/x/branches",
+	"/repos/x/x/branches/",
+	"/repos/x/x/branches/extra",
+	"/repos/x/x/branches/x",
+	"/repos/x/x/branches/x/",
+	"/repos/x/x/branches/x/extra",
+	"/repos/x/x/collaborators",
+	"/repos/x/x/collaborators/",
+	"/repos/x/x/collaborators/extra",
+	"/repos/x/x/collaborators/x",
+	"/repos/x/x/collaborators/x/",
+	"/repos/x/x/collaborators/x/extra",
+	"/repos/x/x/comments",
+	"/repos/x/x/comments/",
+	"/repos/x/x/comments/extra",
+	"/repos/x/x/comments/x",
+	"/repos/x/x/comments/x/",
+	"/repos/x/x/comments/x/extra",
+	"/repos/x/x/commits",
+	"/repos/x/x/commits/",
+	"/repos/x/x/commits/extra",
+	"/repos/x/x/commits/x",
+	"/repos/x/x/commits/x/",
+	"/repos/x/x/commits/x/comments",
+	"/repos/x/x/commits/x/comments/",
+	"/repos/x/x/commits/x/comments/extra",
+	"/repos/x/x/commits/x/extra",
+	"/repos/x/x/contents",
+	"/repos/x/x/contents/",
+	"/repos/x/x/contents/a",
+	"/repos/x/x/contents/a/",
+	"/repos/x/x/contents/a/b",
+	"/repos/x/x/contents/a/b/",
+	"/repos/x/x/contents/a/b/extra",
+	"/repos/x/x/contributors",
+	"/repos/x/x/contributors/",
+	"/repos/x/x/contributors/extra",
+	"/repos/x/x/downloads",
+	"/repos/x/x/downloads/",
+	"/repos/x/x/downloads/extra",
+	"/repos/x/x/downloads/x",
+	"/repos/x/x/downloads/x/",
+	"/repos/x/x/downloads/x/extra",
+	"/repos/x/x/events",
+	"/repos/x/x/events/",
+	"/repos/x/x/events/extra",
+	"/repos/x/x/extra",
+	"/repos/x/x/forks",
+	"/repos/x/x/forks/",
+	"/repos/x/x/forks/extra",
+	"/repos/x/x/git",
+	"/repos/x/x/git/",
+	"/repos/x/x/git/blobs",
+	"/repos/x/x/git/blobs/",
+	"/repos/x/x/git/blobs/extra",
+	"/repos/x/x/git/blobs/x",
+	"/repos/x/x/git/blobs/x/",
+	"/repos/x/x/git/blobs/x/extra",
+	"/repos/x/x/git/commits",
+	"/repos/x/x/git/commits/",
+	"/repos/x/x/git/commits/extra",
+	"/repos/x/x/git/commits/x",
+	"/repos/x/x/git/commits/x/",
+	"/repos/x/x/git/commits/x/extra",
+	"/repos/x/x/git/refs",
+	"/repos/x/x/git/refs/",
+	"/repos/x/x/git/refs/a",
+	"/repos/x/x/git/refs/a/",
+	"/repos/x/x/git/refs/a/b",
+	"/repos/x/x/git/refs/a/b/",
+	"/repos/x/x/git/refs/a/b/extra",
+	"/repos/x/x/git/refs/extra",
+	"/repos/x/x/git/tags",
+	"/repos/x/x/git/tags/",
+	"/repos/x/x/git/tags/extra",
+	"/repos/x/x/git/tags/x",
+	"/repos/x/x/git/tags/x/",
+	"/repos/x/x/git/tags/x/extra",
+	"/repos/x/x/git/trees",
+	"/repos/x/x/git/trees/",
+	"/repos/x/x/git/trees/extra",
+	"/repos/x/x/git/trees/x",
+	"/repos/x/x/git/trees/x/",
+	"/repos/x/x/git/trees/x/extra",
+	"/repos/x/x/hooks",
+	"/repos/x/x/hooks/",
+	"/repos/x/x/hooks/extra",
+	"/repos/x/x/hooks/x",
+	"/repos/x/x/hooks/x/",
+	"/repos/x/x/hooks/x/extra",
+	"/repos/x/x/hooks/x/tests",
+	"/repos/x/x/hooks/x/tests/",
+	"/repos/x/x/hooks/x/tests/extra",
+	"/repos/x/x/issues",
+	"/repos/x/x/issues/",
+	"/repos/x/x/issues/comments",
+	"/repos/x/x/issues/comments/",
+	"/repos/x/x/issues/comments/extra",
+	"/repos/x/x/issues/comments/x",
+	"/repos/x/x/issues/comments/x/",
+	"/repos/x/x/issues/comments/x/extra",
+	"/repos/x/x/issues/events",
+	"/repos/x/x/issues/events/",
+	"/repos/x/x/issues/events/extra",
+	"/repos/x/x/issues/events/x",
+	"/repos/x/x/issues/events/x/",
+	"/repos/x/x/issues/events/x/extra",
+	"/repos/x/x/issues/extra",
+	"/repos/x/x/issues/x",
+	"/repos/x/x/issues/x/",
+	"/repos/x/x/issues/x/comments",
+	"/repos/x/x/issues/x/comments/",
+	"/repos/x/x/issues/x/comments/extra",
+	"/repos/x/x/issues/x/events",
+	"/repos/x/x/issues/x/events/",
+	"/repos/x/x/issues/x/events/extra",
+	"/repos/x/x/issues/x/extra",
+	"/repos/x/x/issues/x/labels",
+	"/repos/x/x/issues/x/labels/",
+	"/repos/x/x/issues/x/labels/extra",
+	"/repos/x/x/issues/x/labels/x",
+	"/repos/x/x/issues/x/labels/x/",
+	"/repos/x/x/issues/x/labels/x/extra",
+	"/repos/x/x/keys",
+	"/repos/x/x/keys/",
+	"/repos/x/x/keys/extra",
+	"/repos/x/x/keys/x",
+	"/repos/x/x/keys/x/",
+	"/repos/x/x/keys/x/extra",
+	"/repos/x/x/labels",
+	"/repos/x/x/labels/",
+	"/repos/x/x/labels/extra",
+	"/repos/x/x/labels/x",
+	"/repos/x/x/labels/x/",
+	"/repos/x/x/labels/x/extra",
+	"/repos/x/x/languages",
+	"/repos/x/x/languages/",
+	"/repos/x/x/languages/extra",
+	"/repos/x/x/merges",
+	"/repos/x/x/merges/",
+	"/repos/x/x/merges/extra",
+	"/repos/x/x/milestones",
+	"/repos/x/x/milestones/",
+	"/repos/x/x/milestones/extra",
+	"/repos/x/x/milestones/x",
+	"/repos/x/x/milestones/x/",
+	"/repos/x/x/milestones/x/extra",
+	"/repos/x/x/milestones/x/labels",
+	"/repos/x/x/milestones/x/labels/",
+	"/repos/x/x/milestones/x/labels/extra",
+	"/repos/x/x/notifications",
+	"/repos/x/x/notifications/",
+	"/repos/x/x/notifications/extra",
+	"/repos/x/x/pulls",
+	"/repos/x/x/pulls/",
+	"/repos/x/x/pulls/comments",
+	"/repos/x/x/pulls/comments/",
+	"/repos/x/x/pulls/comments/extra",
+	"/repos/x/x/pulls/comments/x",
+	"/repos/x/x/pulls/comments/x/",
+	"/repos/x/x/pulls/comments/x/extra",
+	"/repos/x/x/pulls/extra",
+	"/repos/x/x/pulls/x",
+	"/repos/x/x/pulls/x/",
+	"/repos/x/x/pulls/x/comments",
+	"/repos/x/x/pulls/x/comments/",
+	"/repos/x/x/pulls/x/comments/extra",
+	"/repos/x/x/pulls/x/commits",
+	"/repos/x/x/pulls/x/commits/",
+	"/repos/x/x/pulls/x/commits/extra",
+	"/repos/x/x/pulls/x/extra",
+	"/repos/x/x/pulls/x/files",
+	"/repos/x/x/pulls/x/files/",
+	"/repos/x/x/pulls/x/files/extra",
+	"/repos/x/x/pulls/x/merge",
+	"/repos/x/x/pulls/x/merge/",
+	"/repos/x/x/pulls/x/merge/extra",
+	"/repos/x/x/readme",
+	"/repos/x/x/readme/",
+	"/repos/x/x/readme/extra",
+	"/repos/x/x/releases",
+	"/repos/x/x/releases/",
+	"/repos/x/x/releases/extra",
+	"/repos/x/x/releases/x",
+	"/repos/x/x/releases/x/",
+	"/repos/x/x/releases/x/assets",
+	"/repos/x/x/releases/x/assets/",
+	"/repos/x/x/releases/x/assets/extra",
+	"/repos/x/x/releases/x/extra",
+	"/repos/x/x/stargazers",
+	"/repos/x/x/stargazers/",
+	"/repos/x/x/stargazers/extra",
+	"/repos/x/x/stats",
+	"/repos/x/x/stats/",
+	"/repos/x/x/stats/code_frequency",
+	"/repos/x/x/stats/code_frequency/",
+	"/repos/x/x/stats/code_frequency/extra",
+	"/repos/x/x/stats/commit_activity",
+	"/repos/x/x/stats/commit_activity/",
+	"/repos/x/x/stats/commit_activity/extra",
+	"/repos/x/x/stats/contributors",
+	"/repos/x/x/stats/contributors/",
+	"/repos/x/x/stats/contributors/extra",
+	"/repos/x/x/stats/participation",
+	"/repos/x/x/stats/participation/",
+	"/repos/x/x/stats/participation/extra",
+	"/repos/x/x/stats/punch_card",
+	"/repos/x/x/stats/punch_card/",
+	"/repos/x/x/stats/punch_card/extra",
+	"/repos/x/x/statuses",
+	"/repos/x/x/statuses/",
+	"/repos/x/x/statuses/x",
+	"/repos/x/x/statuses/x/",
+	"/repos/x/x/statuses/x/extra",
+	"/repos/x/x/subscribers",
+	"/repos/x/x/subscribers/",
+	"/repos/x/x/subscribers/extra",
+	"/repos/x/x/subscription",
+	"/repos/x/x/subscription/",
+	"/repos/x/x/subscription/extra",
+	"/repos/x/x/tags",
+	"/repos/x/x/tags/",
+	"/repos/x/x/tags/extra",
+	"/repos/x/x/teams",
+	"/repos/x/x/teams/",
+	"/repos/x/x/teams/extra",
+	"/repos/x/x/x",
+	"/repos/x/x/x/",
+	"/repos/x/x/x/x",
+	"/repos/x/x/x/x/",
+	"/repos/x/x/x/x/extra",
+	"/repositories",
+	"/repositories/",
+	"/repositories/extra",
+	"/search",
+	"/search/",
+	"/search/code",
+	"/search/code/",
+	"/search/code/extra",
+	"/search/issues",
+	"/search/issues/",
+	"/search/issues/extra",
+	"/search/repositories",
+	"/search/repositories/",
+	"/search/repositories/extra",
+	"/search/users",
+	"/search/users/",
+	"/search/users/extra",
+	"/teams",
+	"/teams/",
+	"/teams//",
+	"/teams//extra",
+	"/teams//members",
+	"/teams//members/",
+	"/teams//members//",
+	"/teams//members//extra",
+	"/teams//members/extra",
+	"/teams//repos",
+	"/teams//repos/",
+	"/teams//repos//",
+	"/teams//repos///",
+	"/teams//repos///extra",
+	"/teams//repos/extra",
+	"/teams/:id",
+	"/teams/:id/",
+	"/teams/:id/extra",
+	"/teams/:id/members",
+	"/teams/:id/members/",
+	"/teams/:id/members/:user",
+	"/teams/:id/members/:user/",
+	"/teams/:id/members/:user/extra",
+	"/teams/:id/members/extra",
+	"/teams/:id/repos",
+	"/teams/:id/repos/",
+	"/teams/:id/repos/:owner",
+	"/teams/:id/repos/:owner/",
+	"/teams/:id/repos/:owner/:repo",
+	"/teams/:id/repos/:owner/:repo/",
+	"/teams/:id/repos/:owner/:repo/extra",
+	"/teams/:id/repos/extra",
+	"/teams/x",
+	"/teams/x/",
+	"/teams/x/extra",
+	"/teams/x/members",
+	"/teams/x/members/",
+	"/teams/x/members/extra",
+	"/teams/x/members/x",
+	"/teams/x/members/x/",
+	"/teams/x/members/x/extra",
+	"/teams/x/repos",
+	"/teams/x/repos/",
+	"/teams/x/repos/extra",
+	"/teams/x/repos/x",
+	"/teams/x/repos/x/",
+	"/teams/x/repos/x/x",
+	"/teams/x/repos/x/x/",
+	"/teams/x/repos/x/x/extra",
+	"/user",
+	"/user/",
+	"/user/emails",
+	"/user/emails/",
+	"/user/emails/extra",
+	"/user/extra",
+	"/user/followers",
+	"/user/followers/",
+	"/user/followers/extra",
+	"/user/following",
+	"/user/following/",
+	"/user/following//",
+	"/user/following//extra",
+	"/user/following/:user",
+	"/user/following/:user/",
+	"/user/following/:user/extra",
+	"/user/following/extra",
+	"/user/following/x",
+	"/user/following/x/",
+	"/user/following/x/extra",
+	"/user/issues",
+	"/user/issues/",
+	"/user/issues/extra",
+	"/user/keys",
+	"/user/keys/",
+	"/user/keys//",
+	"/user/keys//extra",
+	"/user/keys/:id",
+	"/user/keys/:id/",
+	"/user/keys/:id/extra",
+	"/user/keys/extra",
+	"/user/keys/x",
+	"/user/keys/x/",
+	"/user/keys/x/extra",
+	"/user/orgs",
+	"/user/orgs/",
+	"/user/orgs/extra",
+	"/user/repos",
+	"/user/repos/",
+	"/user/repos/extra",
+	"/user/starred",
+	"/user/starred/",
+	"/user/starred//",
+	"/user/starred///",
+	"/user/starred///extra",
+	"/user/starred/:owner",
+	"/user/starred/:owner/",
+	"/user/starred/:owner/:repo",
+	"/user/starred/:owner/:repo/",
+	"/user/starred/:owner/:repo/extra",
+	"/user/starred/extra",
+	"/user/starred/x",
+	"/user/starred/x/",
+	"/user/starred/x/x",
+	"/user/starred/x/x/",
+	"/user/starred/x/x/extra",
+	"/user/subscriptions",
+	"/user/subscriptions/",
+	"/user/subscriptions//",
+	"/user/subscriptions///",
+	"/user/subscriptions///extra",
+	"/user/subscriptions/:owner",
+	"/user/subscriptions/:owner/",
+	"/user/subscriptions/:owner/:repo",
+	"/user/subscriptions/:owner/:repo/",
+	"/user/subscriptions/:owner/:repo/extra",
+	"/user/subscriptions/extra",
+	"/user/subscriptions/x",
+	"/user/subscriptions/x/",
+	"/user/subscriptions/x/x",
+	"/user/subscriptions/x/x/",
+	"/user/subscriptions/x/x/extra",
+	"/user/teams",
+	"/user/teams/",
+	"/user/teams/extra",
+	"/users",
+	"/users/",
+	"/users//",
+	"/users//events",
+	"/users//events/",
+	"/users//events/extra",
+	"/users//events/orgs",
+	"/users//events/orgs/",
+	"/users//events/orgs//",
+	"/users//events/orgs//extra",
+	"/users//events/public",
+	"/users//events/public/",
+	"/users//events/public/extra",
+	"/users//extra",
+	"/users//followers",
+	"/users//followers/",
+	"/users//followers/extra",
+	"/users//following",
+	"/users//following/",
+	"/users//following//",
+	"/users//following//extra",
+	"/users//following/extra",
+	"/users//gists",
+	"/users//gists/",
+	"/users//gists/extra",
+	"/users//keys",
+	"/users//keys/",
+	"/users//keys/extra",
+	"/users//orgs",
+	"/users//orgs/",
+	"/users//orgs/extra",
+	"/users//received_events",
+	"/users//received_events/",
+	"/users//received_events/extra",
+	"/users//received_events/public",
+	"/users//received_events/public/",
+	"/users//received_events/public/extra",
+	"/users//repos",
+	"/users//repos/",
+	"/users//repos/extra",
+	"/users//starred",
+	"/users//starred/",
+	"/users//starred/extra",
+	"/users//subscriptions",
+	"/users//subscriptions/",
+	"/users//subscriptions/extra",
+	"/users/:user",
+	"/users/:user/",
+	"/users/:user/events",
+	"/users/:user/events/",
+	"/users/:user/events/extra",
+	"/users/:user/events/orgs",
+	"/users/:user/events/orgs/",
+	"/users/:user/events/orgs/:org",
+	"/users/:user/events/orgs/:org/",
+	"/users/:user/events/orgs/:org/extra",
+	"/users/:user/events/public",
+	"/users/:user/events/public/",
+	"/users/:user/events/public/extra",
+	"/users/:user/extra",
+	"/users/:user/followers",
+	"/users/:user/followers/",
+	"/users/:user/followers/extra",
+	"/users/:user/following",
+	"/users/:user/following/",
+	"/users/:user/following/:target_user",
+	"/users/:user/following/:target_user/",
+	"/users/:user/following/:target_user/extra",
+	"/users/:user/following/extra",
+	"/users/:user/gists",
+	"/users/:user/gists/",
+	"/users/:user/gists/extra",
+	"/users/:user/keys",
+	"/users/:user/keys/",
+	"/users/:user/keys/extra",
+	"/users/:user/orgs",
+	"/users/:user/orgs/",
+	"/users/:user/orgs/extra",
+	"/users/:user/received_events",
+	"/users/:user/received_events/",
+	"/users/:user/received_events/extra",
+	"/users/:user/received_events/public",
+	"/users/:user/received_events/public/",
+	"/users/:user/received_events/public/extra",
+	"/users/:user/repos",
+	"/users/:user/repos/",
+	"/users/:user/repos/extra",
+	"/users/:user/starred",
+	"/users/:user/starred/",
+	"/users/:user/starred/extra",
+	"/users/:user/subscriptions",
+	"/users/:user/subscriptions/",
+	"/users/:user/subscriptions/extra",
+	"/users/extra",
+	"/users/x",
+	"/users/x/",
+	"/users/x/events",
+	"/users/x/events/",
+	"/users/x/events/extra",
+	"/users/x/events/orgs",
+	"/users/x/events/orgs/",
+	"/users/x/events/orgs/x",
+	"/users/x/events/orgs/x/",
+	"/users/x/events/orgs/x/extra",
+	"/users/x/events/public",
+	"/users/x/events/public/",
+	"/users/x/events/public/extra",
+	"/users/x/extra",
+	"/users/x/followers",
+	"/users/x/followers/",
+	"/users/x/followers/extra",
+	"/users/x/following",
+	"/users/x/following/",
+	"/users/x/following/extra",
+	"/users/x/following/x",
+	"/users/x/following/x/",
+	"/users/x/following/x/extra",
+	"/users/x/gists",
+	"/users/x/gists/",
+	"/users/x/gists/extra",
+	"/users/x/keys",
+	"/users/x/keys/",
+	"/users/x/keys/extra",
+	"/users/x/orgs",
+	"/users/x/orgs/",
+	"/users/x/orgs/extra",
+	"/users/x/received_events",
+	"/users/x/received_events/",
+	"/users/x/received_events/extra",
+	"/users/x/received_events/public",
+	"/users/x/received_events/public/",
+	"/users/x/received_events/public/extra",
+	"/users/x/repos",
+	"/users/x/repos/",
+	"/users/x/repos/extra",
+	"/users/x/starred",
+	"/users/x/starred/",
+	"/users/x/starred/extra",
+	"/users/x/subscriptions",
+	"/users/x/subscriptions/",
+	"/users/x/subscriptions/extra",
+}
+
+func TestMatcherEquivalence(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	tree, generated := vestigo.NewRouter(), vestigo.NewRouter()
+	for _, route := range matcherTestRoutes {
+		tree.Add(route[0], route[1], ok)
+		generated.Add(route[0], route[1], ok)
+	}
+	if err := generated.SetMatcher(Matcher{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range matcherTestPaths {
+		for _, method := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"} {
+			h1, params1, template1, allowed1 := tree.Lookup(method, path)
+			h2, params2, template2, allowed2 := generated.Lookup(method, path)
+			if (h1 == nil) != (h2 == nil) || !reflect.DeepEqual(params1, params2) || template1 != template2 || !reflect.DeepEqual(allowed1, allowed2) {
+				t.Errorf("%s %s: tree matched %q %v %v, generated matcher %q %v %v", method, path, template1, params1, allowed1, template2, params2, allowed2)
+			}
+
+			w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+			tree.ServeHTTP(w1, httptest.NewRequest(method, path, nil))
+			generated.ServeHTTP(w2, httptest.NewRequest(method, path, nil))
+			if w1.Code != w2.Code || w1.Header().Get("Allow") != w2.Header().Get("Allow") {
+				t.Errorf("%s %s: tree answered %d, generated matcher %d", method, path, w1.Code, w2.Code)
+			}
+		}
+	}
+}
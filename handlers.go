@@ -98,11 +98,12 @@ var (
 	}
 
 	// optionsHandler - Generic Options Handler to handle when method isn't allowed for a resource
-	optionsHandler = func(gcors *CorsAccessControl, lcors *CorsAccessControl, allowedMethods string) func(w http.ResponseWriter, r *http.Request) {
+	optionsHandler = func(router *Router, res *resource, allowed methodSet, template string) func(w http.ResponseWriter, r *http.Request) {
 		return func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Add("Allow", allowedMethods)
+			w.Header().Set("Allow", allowed.String())
 
-			if err := corsPreflight(gcors, lcors, allowedMethods, w, r); err != nil {
+			cors := router.corsPolicy(res, template, r.Header.Get("Access-Control-Request-Method"))
+			if err := corsPreflight(router, cors, allowed, template, w, r); err != nil {
 				return
 			}
 			w.WriteHeader(http.StatusOK)
@@ -112,7 +113,7 @@ var (
 	// methodNotAllowedHandler - Generic Handler to handle when method isn't allowed for a resource
 	methodNotAllowedHandler = func(allowedMethods string) func(w http.ResponseWriter, r *http.Request) {
 		return func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Add("Allow", allowedMethods)
+			w.Header().Set("Allow", allowedMethods)
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			w.Write([]byte(http.StatusText(http.StatusMethodNotAllowed)))
 		}
@@ -122,42 +123,4 @@ var (
 		w.WriteHeader(http.StatusNotFound)
 		w.Write([]byte(http.StatusText(http.StatusNotFound)))
 	}
-
-	// corsFlightWrapper - Wrap the handler in cors
-	corsFlightWrapper = func(gcors *CorsAccessControl, lcors *CorsAccessControl, allowedMethods string, f func(http.ResponseWriter, *http.Request)) func(w http.ResponseWriter, r *http.Request) {
-		return func(w http.ResponseWriter, r *http.Request) {
-
-			if origin := r.Header.Get("Origin"); origin != "" {
-				cors := gcors.Merge(lcors)
-				if cors != nil {
-					// validate origin is in list of acceptable allow-origins
-					allowedOrigin := false
-					allowedOriginExact := false
-					for _, v := range cors.GetAllowOrigin() {
-						if v == origin {
-							w.Header().Add("Access-Control-Allow-Origin", origin)
-							allowedOriginExact = true
-							allowedOrigin = true
-							break
-						}
-					}
-					if !allowedOrigin {
-						for _, v := range cors.GetAllowOrigin() {
-							if v == "*" {
-								w.Header().Add("Access-Control-Allow-Origin", v)
-								allowedOrigin = true
-								break
-							}
-						}
-					}
-
-					// if allow credentials is allowed on this resource respond with true
-					if allowCredentials := cors.GetAllowCredentials(); allowedOriginExact && allowCredentials {
-						w.Header().Add("Access-Control-Allow-Credentials", "true")
-					}
-				}
-			}
-			f(w, r)
-		}
-	}
 )
@@ -224,6 +224,9 @@ func TestCustomMethodNotAllowed(t *testing.T) {
 	if err != nil {
 		t.Errorf("Failed to create a new request, method: %s, path: %s", "GET", path)
 	}
+	defer func(original MethodNotAllowedHandlerFunc) {
+		methodNotAllowedHandler = original
+	}(methodNotAllowedHandler)
 	CustomMethodNotAllowedHandlerFunc(func(a string) func(w http.ResponseWriter, r *http.Request) {
 		return func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -236,3 +239,43 @@ func TestCustomMethodNotAllowed(t *testing.T) {
 		t.Errorf("Invalid response, method: %s, path: %s, code: %d, body: %s", "GET", path, w.Code, w.Body.String())
 	}
 }
+
+func TestAllowHeaderIsCanonical(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+	getFirst, postFirst := NewRouter(), NewRouter()
+	getFirst.Get("/test", h)
+	getFirst.Post("/test", h)
+	postFirst.Post("/test", h)
+	postFirst.Get("/test", h)
+
+	for _, method := range []string{"DELETE", "OPTIONS"} {
+		w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+		r1, _ := http.NewRequest(method, "/test", nil)
+		r2, _ := http.NewRequest(method, "/test", nil)
+		getFirst.ServeHTTP(w1, r1)
+		postFirst.ServeHTTP(w2, r2)
+		if allow := w1.Header().Get("Allow"); allow != "GET, HEAD, POST" || w2.Header().Get("Allow") != allow {
+			t.Errorf("Allow differs with registration order, method: %s, %q and %q", method, allow, w2.Header().Get("Allow"))
+		}
+	}
+}
+
+func TestAllowHeaderTraceOnce(t *testing.T) {
+	AllowTrace = true
+	defer func() {
+		AllowTrace = false
+	}()
+
+	h := func(w http.ResponseWriter, r *http.Request) {}
+	router := NewRouter()
+	router.Get("/test", h)
+	router.Put("/test", h)
+	router.Delete("/test", h)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/test", nil)
+	router.ServeHTTP(w, r)
+	if allow := w.Header().Get("Allow"); allow != "GET, HEAD, PUT, DELETE, TRACE" {
+		t.Errorf("Invalid Allow header with AllowTrace: %q", allow)
+	}
+}
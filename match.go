@@ -0,0 +1,178 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// RouteMatch - Describes the route a request matched
+type RouteMatch struct {
+	// Template - the path template of the route, such as /users/:id
+	Template string
+	// Name - the name given to the route with Router.SetName
+	Name string
+	// Params - the url params captured from the request path
+	Params map[string]string
+	// AllowedMethods - the methods the route has handlers for
+	AllowedMethods []string
+	// MethodAllowed - whether the route has a handler for the request method
+	MethodAllowed bool
+	// Cors - the CORS policy in effect for the request method on the route
+	Cors *CorsAccessControl
+	// Metadata - the metadata attached to the route with Router.SetMetadata
+	Metadata map[string]interface{}
+}
+
+// MatchFromRequest - Get the RouteMatch of the route a request dispatched by a
+// Router matched, nil is returned if the request did not match any route.  The
+// RouteMatch is shared, and should not be modified.
+func MatchFromRequest(r *http.Request) *RouteMatch {
+	m, _ := r.Context().Value(matchKey).(*RouteMatch)
+	return m
+}
+
+// Matched - Reports whether the request matched a route with a handler for the
+// request method, as opposed to being answered by the router's not found,
+// method not allowed or OPTIONS handling.  This is handy in router wide
+// middleware added with Router.Use.
+func Matched(r *http.Request) bool {
+	m := MatchFromRequest(r)
+	return m != nil && m.MethodAllowed
+}
+
+// maxParams - the number of url params a paramsContext holds without allocating
+const maxParams = 8
+
+// routeContext - the route a request is dispatched to, which is the context of
+// the request the router serves.  Every request gets its own, which stays valid
+// for as long as anything holds on to the request context, and which only has
+// room for url param values when the route has any, see paramsContext.
+type routeContext struct {
+	context.Context
+	router  *Router
+	handler http.HandlerFunc
+	// node - the node of the route, nil when the request matched no route
+	node *node
+	// method - the method the route is described for, which for a preflight
+	// is the method the preflight asks about
+	method        string
+	template      string
+	methodAllowed bool
+	// compiled - whether the handler is the one worked out by Compile, which
+	// applies the CORS policy of the route itself
+	compiled bool
+	// allowed - the methods of the route whose constraints the url params meet
+	allowed methodSet
+	values  []string
+	match   *RouteMatch
+}
+
+// paramsContext - the routeContext of a request whose route has url params,
+// holding their values in the same allocation
+type paramsContext struct {
+	routeContext
+	storage [maxParams]string
+}
+
+// newRouteContext - resolve the request into a routeContext of its own, which
+// is a paramsContext when the route has url params
+func (r *Router) newRouteContext(req *http.Request) *routeContext {
+	// the values are only copied out of storage, which stays on the stack
+	var (
+		storage  [maxParams]string
+		resolved routeContext
+	)
+	values := r.resolve(&resolved, req, storage[:0])
+	if len(values) == 0 {
+		rc := new(routeContext)
+		*rc = resolved
+		return rc
+	}
+	p := &paramsContext{routeContext: resolved}
+	p.values = append(p.storage[:0], values...)
+	return &p.routeContext
+}
+
+// paramStoragePool - storage for the url param values of lookups, which only
+// hand out copies of them
+var paramStoragePool = sync.Pool{
+	New: func() interface{} {
+		return new([maxParams]string)
+	},
+}
+
+// Value - implementation of context.Context, answering the RouteMatch and the
+// routeContext itself, and delegating every other key to the parent context
+func (c *routeContext) Value(key interface{}) interface{} {
+	switch key {
+	case matchKey:
+		return c.routeMatch()
+	case routeKey:
+		return c
+	case routerKey{c.router}:
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+// routerKey - key of the routeContext of a particular router, as opposed to
+// routeKey, which gets the one of the innermost router serving the request
+type routerKey struct {
+	router *Router
+}
+
+// routeMatch - the RouteMatch of the route, built the first time it is asked for
+func (c *routeContext) routeMatch() *RouteMatch {
+	if c.match != nil || c.node == nil {
+		return c.match
+	}
+	res := c.node.resource
+	c.match = &RouteMatch{
+		Template:       c.template,
+		Name:           c.router.names[c.template],
+		Params:         paramMap(c.node.pnamesFor(c.method), c.values),
+		AllowedMethods: c.allowed.copyList(),
+		MethodAllowed:  c.methodAllowed,
+		Cors:           c.router.corsPolicy(res, c.template, c.method),
+		Metadata:       c.router.metadata[c.template],
+	}
+	return c.match
+}
+
+// param - get a url param of the route by name
+func (c *routeContext) param(name string) (string, bool) {
+	if c.node == nil {
+		return "", false
+	}
+	for i, pname := range c.node.pnamesFor(c.method) {
+		if pname == name && i < len(c.values) {
+			return c.values[i], true
+		}
+	}
+	return "", false
+}
+
+// routeContextFrom - get the routeContext of a request the router is serving
+func routeContextFrom(r *http.Request) *routeContext {
+	rc, _ := r.Context().Value(routeKey).(*routeContext)
+	return rc
+}
+
+// paramMap - pair the url param values captured with their names
+func paramMap(pnames, values []string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	params := make(map[string]string, len(values))
+	for i, v := range values {
+		if i < len(pnames) {
+			params[pnames[i]] = v
+		}
+	}
+	return params
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFromRequest(t *testing.T) {
+	r := NewRouter()
+	r.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com"}})
+
+	var fromMiddleware, fromHandler *RouteMatch
+	r.Use(func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			fromMiddleware = MatchFromRequest(req)
+			f(w, req)
+		}
+	})
+	r.Get("/users/:id/files/*", func(w http.ResponseWriter, req *http.Request) {
+		fromHandler = MatchFromRequest(req)
+	})
+	r.Post("/users/:id/files/*", func(w http.ResponseWriter, req *http.Request) {})
+	r.SetName("/users/:id/files/*", "user-files")
+	r.SetMetadata("/users/:id/files/*", "owner", "storage-team")
+
+	req, _ := http.NewRequest("GET", "/users/1/files/a/b", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if assert.NotNil(t, fromHandler) {
+		assert.Equal(t, fromMiddleware, fromHandler)
+		assert.Equal(t, "/users/:id/files/*", fromHandler.Template)
+		assert.Equal(t, "user-files", fromHandler.Name)
+		assert.Equal(t, map[string]string{"id": "1", "_name": "a/b"}, fromHandler.Params)
+		assert.Equal(t, []string{"GET", "HEAD", "POST"}, fromHandler.AllowedMethods)
+		assert.True(t, fromHandler.MethodAllowed)
+		assert.Equal(t, []string{"test.com"}, fromHandler.Cors.AllowOrigin)
+		assert.Equal(t, "storage-team", fromHandler.Metadata["owner"])
+	}
+
+	// router generated responses still describe the route
+	fromMiddleware = nil
+	req, _ = http.NewRequest("DELETE", "/users/1/files/a", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	if assert.NotNil(t, fromMiddleware) {
+		assert.Equal(t, "user-files", fromMiddleware.Name)
+		assert.False(t, fromMiddleware.MethodAllowed)
+	}
+
+	fromMiddleware = nil
+	req, _ = http.NewRequest("GET", "/groups/1", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Nil(t, fromMiddleware)
+}
+
+func TestRouterMatch(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, req *http.Request) {})
+
+	req, _ := http.NewRequest("GET", "/users/1?a=b", nil)
+	m := r.Match(req)
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "/users/:id", m.Template)
+		assert.Equal(t, "1", m.Params["id"])
+	}
+	// no params are added to the request
+	assert.Equal(t, "a=b", req.URL.RawQuery)
+
+	req, _ = http.NewRequest("GET", "/groups/1", nil)
+	assert.Nil(t, r.Match(req))
+}
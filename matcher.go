@@ -0,0 +1,110 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import "errors"
+
+// Matcher - A replacement for the walk of the router tree, such as the matchers
+// generated by the gen package from a route table.  Match appends the url param
+// values captured from the path to values, and returns the index of the node of
+// the tree the path matches in MatcherNodes order, or -1 when it matches none.
+// Nodes returns the keys of the nodes of the tree the matcher was made for.
+type Matcher interface {
+	Match(path string, values []string) (int, []string)
+	Nodes() []string
+}
+
+// MatcherNode - A read only description of a node of the router tree, for
+// generating matchers.  Nodes refer to each other by index, -1 being none.
+type MatcherNode struct {
+	// Key - the prefixes of the node and of its ancestors, which identifies the node
+	Key string
+	// Prefix - the part of the path the node matches, ":" and "*" for param and
+	// match-any nodes
+	Prefix string
+	Parent int
+	// Static - the static children, by label
+	Static []int
+	// Param, Any - the param and match-any children
+	Param int
+	Any   int
+	// ParamLabel, AnyLabel - whether a child is labelled ":" or "*"
+	ParamLabel bool
+	AnyLabel   bool
+	// Leaf - whether the node has no children
+	Leaf bool
+	// Allowed - whether any method is allowed on the node
+	Allowed bool
+}
+
+// MatcherNodes - Describe the nodes of the router tree, breadth first, for
+// generating matchers.  Add every route before calling it.
+func (r *Router) MatcherNodes() []MatcherNode {
+	nodes := r.root.breadthFirst()
+	index := make(map[*node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+	indexOf := func(n *node) int {
+		if n == nil {
+			return -1
+		}
+		return index[n]
+	}
+
+	described := make([]MatcherNode, len(nodes))
+	for i, n := range nodes {
+		d := MatcherNode{
+			Prefix:     n.prefix,
+			Parent:     indexOf(n.parent),
+			Param:      indexOf(n.paramChild),
+			Any:        indexOf(n.anyChild),
+			ParamLabel: n.findChildWithLabel(':') != nil,
+			AnyLabel:   n.findChildWithLabel('*') != nil,
+			Leaf:       len(n.children) == 0,
+			Allowed:    n.resource != nil && n.resource.methods != 0,
+		}
+		if d.Parent >= 0 {
+			d.Key = described[d.Parent].Key
+		}
+		d.Key += n.prefix
+		for _, c := range n.indexed {
+			if c.typ == stype {
+				d.Static = append(d.Static, index[c])
+			}
+		}
+		described[i] = d
+	}
+	return described
+}
+
+// SetMatcher - Match requests with the matcher instead of walking the router
+// tree.  An error is returned when the matcher was made for a different route
+// table.  Set the matcher once every route is added, adding routes afterwards
+// panics.
+func (r *Router) SetMatcher(m Matcher) error {
+	nodes := r.root.breadthFirst()
+	keys := m.Nodes()
+	if len(keys) != len(nodes) {
+		return errors.New("vestigo: matcher was made for a different route table")
+	}
+	for i, d := range r.MatcherNodes() {
+		if keys[i] != d.Key {
+			return errors.New("vestigo: matcher was made for a different route table, node " + d.Key + " differs")
+		}
+	}
+	r.matcher, r.matcherNodes = m, nodes
+	return nil
+}
+
+// breadthFirst - this node and every node below it, breadth first, children in
+// the order they were added
+func (n *node) breadthFirst() []*node {
+	nodes := []*node{n}
+	for i := 0; i < len(nodes); i++ {
+		nodes = append(nodes, nodes[i].children...)
+	}
+	return nodes
+}
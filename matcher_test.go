@@ -0,0 +1,89 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// treeMatcher - a Matcher walking a copy of the router tree
+type treeMatcher struct {
+	r     *Router
+	nodes map[*node]int
+}
+
+func newTreeMatcher(r *Router) *treeMatcher {
+	m := &treeMatcher{r: r, nodes: make(map[*node]int)}
+	for i, n := range r.root.breadthFirst() {
+		m.nodes[n] = i
+	}
+	return m
+}
+
+func (m *treeMatcher) Match(path string, values []string) (int, []string) {
+	n, values := m.r.lookup(path, values)
+	if n == nil {
+		return -1, values
+	}
+	return m.nodes[n], values
+}
+
+func (m *treeMatcher) Nodes() []string {
+	var keys []string
+	for _, n := range m.r.MatcherNodes() {
+		keys = append(keys, n.Key)
+	}
+	return keys
+}
+
+func TestRouter_SetMatcher(t *testing.T) {
+	build := func(paths ...string) *Router {
+		r := NewRouter()
+		for _, path := range paths {
+			r.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+		}
+		return r
+	}
+
+	r := build("/users/:id", "/users/:id/files/*", "/health")
+	assert.NoError(t, r.SetMatcher(newTreeMatcher(build("/users/:id", "/users/:id/files/*", "/health"))))
+	_, params, template, _ := r.Lookup("GET", "/users/1/files/a/b")
+	assert.Equal(t, "/users/:id/files/*", template)
+	assert.Equal(t, map[string]string{"id": "1", "_name": "a/b"}, params)
+	_, _, template, _ = r.Lookup("GET", "/nope")
+	assert.Equal(t, "", template)
+
+	assert.Panics(t, func() {
+		r.Get("/more", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	r = build("/users/:id", "/health")
+	assert.Error(t, r.SetMatcher(newTreeMatcher(build("/users/:id", "/healthz"))))
+	assert.Error(t, r.SetMatcher(newTreeMatcher(build("/users/:id"))))
+}
+
+func TestRouter_MatcherNodes(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/users/*", func(w http.ResponseWriter, r *http.Request) {})
+
+	nodes := r.MatcherNodes()
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = n.Key
+	}
+	assert.Equal(t, []string{"/users/", "/users/:", "/users/*"}, keys)
+	assert.Equal(t, -1, nodes[0].Parent)
+	assert.Equal(t, 1, nodes[0].Param)
+	assert.Equal(t, 2, nodes[0].Any)
+	assert.True(t, nodes[0].ParamLabel)
+	assert.True(t, nodes[0].AnyLabel)
+	assert.False(t, nodes[0].Allowed)
+	assert.True(t, nodes[1].Allowed)
+	assert.True(t, nodes[1].Leaf)
+}
@@ -0,0 +1,98 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Mount - Serve every request under prefix with h, whatever its method, h being
+// another Router, an http.FileServer, an http.ServeMux or any other handler.
+// The prefix, which can have url params, is stripped from the path and raw path
+// of the request h is given, and OriginalPath recovers the path as the router
+// got it.  The url params of the prefix are kept in the request query, where
+// Param finds them.  Routes added under prefix take precedence over the mount,
+// and paths outside of it are not found as usual.  OPTIONS requests are passed
+// on to h like any other, so h answers CORS preflights for the paths under
+// prefix, as a mounted Router does with its own CORS policies.  TRACE requests
+// are only passed on when AllowTrace is set.
+func (r *Router) Mount(prefix string, h http.Handler) {
+	if strings.Contains(prefix, "*") {
+		panic("vestigo: mount prefix " + prefix + " can not have a wildcard")
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	mounted := mountHandler(strings.Count(prefix, "/"), h)
+	if prefix != "" {
+		r.mount(prefix, mounted)
+	}
+	r.mount(prefix+"/*", mounted)
+}
+
+// mount - add the handler of a mount for every method of the path
+func (r *Router) mount(path string, h http.HandlerFunc) {
+	for method := range methods {
+		switch method {
+		case http.MethodHead, http.MethodOptions, http.MethodTrace:
+			// GET gives HEAD, OPTIONS and TRACE are set below
+			continue
+		}
+		r.Add(method, path, h)
+	}
+	if AllowTrace {
+		// last, as adding the other methods sets the router's TRACE handler
+		r.Add(http.MethodTrace, path, h)
+	}
+	if strings.ContainsAny(path, "{}") {
+		path, _, _ = parseVarPattern(path)
+	}
+	if n := r.root.findNode(strippedPath(path)); n != nil {
+		n.resource.options = h
+	}
+}
+
+// OriginalPath - Get the path of a request before a mount stripped its prefix,
+// which is the path of the request when it was not served by a mount
+func OriginalPath(r *http.Request) string {
+	if path, ok := r.Context().Value(mountKey).(string); ok {
+		return path
+	}
+	return r.URL.Path
+}
+
+// mountHandler - serve requests with h, the first segments of the request path,
+// which the mount prefix matched, stripped
+func mountHandler(segments int, h http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if _, ok := ctx.Value(mountKey).(string); !ok {
+			// mounts within mounts keep the path of the outermost one
+			ctx = context.WithValue(ctx, mountKey, r.URL.Path)
+		}
+		r2 := r.WithContext(ctx)
+		u := *r.URL
+		u.Path = stripSegments(u.Path, segments)
+		if u.RawPath != "" {
+			u.RawPath = stripSegments(u.RawPath, segments)
+		}
+		r2.URL = &u
+		h.ServeHTTP(w, r2)
+	}
+}
+
+// stripSegments - strip the first segments of a path, what is left of it
+// starting with a slash
+func stripSegments(path string, segments int) string {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			if segments == 0 {
+				return path[i:]
+			}
+			segments--
+		}
+	}
+	return "/"
+}
@@ -0,0 +1,161 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Mount(t *testing.T) {
+	sub := NewRouter()
+	sub.Get("/", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("index " + Param(req, "org")))
+	})
+	sub.Post("/repos/:name", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("repo " + Param(req, "org") + " " + Param(req, "name") + " " + OriginalPath(req)))
+	})
+
+	legacy := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.Method + " " + req.URL.Path + " " + req.URL.RawPath + " " + OriginalPath(req)))
+	})
+
+	r := NewRouter()
+	r.Mount("/orgs/:org/", sub)
+	r.Mount("/legacy", legacy)
+	r.Get("/legacy/new", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("new"))
+	})
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/orgs/acme", 200, "index acme"},
+		{"GET", "/orgs/acme/", 200, "index acme"},
+		{"POST", "/orgs/acme/repos/vestigo", 200, "repo acme vestigo /orgs/acme/repos/vestigo"},
+		{"GET", "/orgs/acme/repos/vestigo", 405, "Method Not Allowed"},
+		{"GET", "/orgs/acme/nope", 404, ""},
+		{"DELETE", "/legacy", 200, "DELETE /  /legacy"},
+		{"PUT", "/legacy/a/b", 200, "PUT /a/b  /legacy/a/b"},
+		{"GET", "/legacy/a%2Fb", 200, "GET /a/b /a%2Fb /legacy/a/b"},
+		{"GET", "/legacy/new", 200, "new"},
+		{"GET", "/legacyx", 404, ""},
+		{"OPTIONS", "/legacy/a", 200, "OPTIONS /a  /legacy/a"},
+		{"OPTIONS", "/legacy", 200, "OPTIONS /  /legacy"},
+		{"TRACE", "/legacy/a", 405, "Method Not Allowed"},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		if c.body != "" {
+			assert.Equal(t, c.body, w.Body.String(), c.method+" "+c.path)
+		}
+	}
+}
+
+func TestRouter_MountTrace(t *testing.T) {
+	AllowTrace = true
+	defer func() {
+		AllowTrace = false
+	}()
+	r := NewRouter()
+	r.Mount("/legacy", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.Method + " " + req.URL.Path))
+	}))
+
+	req, _ := http.NewRequest("TRACE", "/legacy/a", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "TRACE /a", w.Body.String())
+}
+
+func TestRouter_MountLookupOptions(t *testing.T) {
+	r := NewRouter()
+	r.Mount("/legacy", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("mounted"))
+	}))
+	r.Get("/users", func(w http.ResponseWriter, req *http.Request) {})
+
+	h, _, _, _ := r.Lookup("OPTIONS", "/legacy/a")
+	if assert.NotNil(t, h, "lookup should give the mount for OPTIONS, as dispatch does") {
+		req, _ := http.NewRequest("OPTIONS", "/legacy/a", nil)
+		w := httptest.NewRecorder()
+		h(w, req)
+		assert.Equal(t, "mounted", w.Body.String())
+	}
+	h, _, _, _ = r.Lookup("OPTIONS", "/users")
+	assert.Nil(t, h, "the router answers OPTIONS for other routes")
+}
+
+func TestRouter_AddOptionsIgnored(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users", func(w http.ResponseWriter, req *http.Request) {})
+	r.Add("OPTIONS", "/users", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("mine"))
+	})
+
+	req, _ := http.NewRequest("OPTIONS", "/users", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "", w.Body.String(), "the router should still answer OPTIONS")
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+}
+
+func TestRouter_MountPreflight(t *testing.T) {
+	sub := NewRouter()
+	sub.SetGlobalCors(&CorsAccessControl{AllowOrigin: []string{"test.com"}})
+	sub.Post("/repos/:name", func(w http.ResponseWriter, req *http.Request) {})
+
+	r := NewRouter()
+	r.Mount("/orgs/:org", sub)
+
+	// the mounted router answers the preflight with its own policy
+	req, _ := http.NewRequest("OPTIONS", "/orgs/acme/repos/vestigo", nil)
+	req.Header.Set("Origin", "test.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "test.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "POST", w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestRouter_MountMatchedPathTemplate(t *testing.T) {
+	r, sub := NewRouter(), NewRouter()
+	var templates []string
+	sub.Get("/x/:y", func(w http.ResponseWriter, req *http.Request) {
+		templates = append(templates, r.GetMatchedPathTemplate(req), sub.GetMatchedPathTemplate(req),
+			MatchFromRequest(req).Template)
+	})
+	r.Mount("/orgs/:org", sub)
+
+	req, _ := http.NewRequest("GET", "/orgs/acme/x/1", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, []string{"/orgs/:org/*", "/x/:y", "/x/:y"}, templates)
+}
+
+func TestRouter_MountNested(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.URL.Path + " " + OriginalPath(req)))
+	})
+	sub := NewRouter()
+	sub.Mount("/v1", inner)
+	r := NewRouter()
+	r.Mount("/api", sub)
+
+	req, _ := http.NewRequest("GET", "/api/v1/users", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "/users /api/v1/users", w.Body.String())
+	assert.Equal(t, "/api/v1/users", req.URL.Path, "the original request should be left alone")
+}
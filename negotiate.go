@@ -0,0 +1,267 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RouteBuilder - Declares a route, with what it produces and consumes, see
+// Router.Route
+type RouteBuilder struct {
+	router   *Router
+	method   string
+	path     string
+	produces []string
+	consumes []string
+	version  string
+}
+
+// Route - Start declaring a route of the method and path, which is added by
+// Handle.  Several handlers can be added to the same method and path, each
+// producing or consuming different media types, the router choosing among them
+// by the Accept and Content-Type headers of requests, which are answered with
+// 406 Not Acceptable or 415 Unsupported Media Type when no handler fits.
+//
+//	router.Route("GET", "/reports/:id").Produces("application/json").Handle(jsonReport)
+//	router.Route("GET", "/reports/:id").Produces("text/csv").Handle(csvReport)
+func (r *Router) Route(method, path string) *RouteBuilder {
+	return &RouteBuilder{router: r, method: method, path: path}
+}
+
+// Produces - Declare the media types the handler responds with, such as
+// application/json.  The handler then only serves requests accepting one of
+// them, and the Content-Type of its responses is set to the one accepted.
+func (b *RouteBuilder) Produces(types ...string) *RouteBuilder {
+	b.produces = append(b.produces, types...)
+	return b
+}
+
+// Consumes - Declare the media types of the request bodies the handler reads,
+// such as application/json or text/*.  The handler then only serves requests
+// without a body or with a Content-Type among them.
+func (b *RouteBuilder) Consumes(types ...string) *RouteBuilder {
+	b.consumes = append(b.consumes, types...)
+	return b
+}
+
+// Version - Declare the API version the handler serves, see Router.Versioning
+func (b *RouteBuilder) Version(version string) *RouteBuilder {
+	b.version = version
+	return b
+}
+
+// Handle - Add the route, with the handler and middleware given
+func (b *RouteBuilder) Handle(h http.HandlerFunc, middleware ...Middleware) {
+	r := b.router
+	key := negotiationKey{b.method, b.path}
+	n := r.negotiators[key]
+	if n == nil {
+		n = new(negotiator)
+		r.add(b.method, b.path, n.serve, nil)
+		if r.negotiators == nil {
+			r.negotiators = make(map[negotiationKey]*negotiator)
+		}
+		r.negotiators[key] = n
+	}
+	n.variants = append(n.variants, variant{
+		handler:  buildChain(h, middleware...),
+		produces: b.produces,
+		consumes: b.consumes,
+		version:  b.version,
+	})
+}
+
+// negotiationKey - key of the negotiators of a router, by method and path
+type negotiationKey struct {
+	method string
+	path   string
+}
+
+// negotiator - the handlers of a route, chosen among by content negotiation
+type negotiator struct {
+	variants []variant
+}
+
+// variant - a handler of a route, the media types it produces and consumes,
+// and the version it serves
+type variant struct {
+	handler  http.HandlerFunc
+	produces []string
+	consumes []string
+	version  string
+}
+
+func (n *negotiator) serve(w http.ResponseWriter, r *http.Request) {
+	version, ok := n.version(APIVersion(r))
+	if !ok {
+		notFoundHandler(w, r)
+		return
+	}
+
+	var (
+		accept     = parseAccept(r.Header.Get("Accept"))
+		consumable bool
+		best       *variant
+		bestType   string
+		bestQ      float64
+	)
+	for i := range n.variants {
+		v := &n.variants[i]
+		if v.version != "" && v.version != version || !v.consumable(r) {
+			continue
+		}
+		consumable = true
+		if t, q := v.acceptable(accept); q > bestQ {
+			best, bestType, bestQ = v, t, q
+		}
+	}
+
+	if len(n.variants) > 1 || len(n.variants[0].produces) > 0 {
+		addVary(w.Header(), "Accept")
+	}
+	switch {
+	case !consumable:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		w.Write([]byte(http.StatusText(http.StatusUnsupportedMediaType)))
+	case best == nil:
+		w.WriteHeader(http.StatusNotAcceptable)
+		w.Write([]byte(http.StatusText(http.StatusNotAcceptable)))
+	default:
+		if bestType != "" && !strings.Contains(bestType, "*") {
+			w.Header().Set("Content-Type", bestType)
+		}
+		best.handler(w, r)
+	}
+}
+
+// version - the version of the route serving the version asked for, which is
+// the newest one not newer than it, the newest one when none is asked for, and
+// whether the route serves the version asked for at all
+func (n *negotiator) version(asked string) (string, bool) {
+	var version string
+	unversioned := false
+	for _, v := range n.variants {
+		switch {
+		case v.version == "":
+			unversioned = true
+		case asked != "" && compareVersions(v.version, asked) > 0:
+		case version == "" || compareVersions(v.version, version) > 0:
+			version = v.version
+		}
+	}
+	return version, version != "" || unversioned
+}
+
+// consumable - whether the variant reads the body of the request
+func (v *variant) consumable(r *http.Request) bool {
+	if len(v.consumes) == 0 {
+		return true
+	}
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return r.ContentLength == 0 && (r.Body == nil || r.Body == http.NoBody)
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	for _, t := range v.consumes {
+		if mediaRangeMatches(t, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptable - the media type the variant responds to the request with, and the
+// quality the request gives it, 0 when it does not accept it
+func (v *variant) acceptable(accept []mediaRange) (string, float64) {
+	if len(v.produces) == 0 {
+		return "", acceptQuality(accept, "*/*")
+	}
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, t := range v.produces {
+		if q := acceptQuality(accept, t); q > bestQ {
+			best, bestQ = t, q
+		}
+	}
+	return best, bestQ
+}
+
+// mediaRange - a media range of an Accept header, with its quality
+type mediaRange struct {
+	typ string
+	q   float64
+}
+
+// parseAccept - parse an Accept header, nil when the request has none
+func parseAccept(header string) []mediaRange {
+	if header == "" {
+		return nil
+	}
+	var ranges []mediaRange
+	for _, v := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType, q})
+	}
+	return ranges
+}
+
+// acceptQuality - the quality of a media type, which can be a range, by the
+// most specific range of the Accept header matching it
+func acceptQuality(accept []mediaRange, mediaType string) float64 {
+	if accept == nil {
+		return 1
+	}
+	q, specificity := 0.0, -1
+	for _, r := range accept {
+		if !mediaRangeMatches(r.typ, mediaType) && !mediaRangeMatches(mediaType, r.typ) &&
+			!mediaRangeMatches(suffixType(r.typ), mediaType) {
+			continue
+		}
+		s := 2 - strings.Count(r.typ, "*")
+		if s > specificity || s == specificity && r.q > q {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// mediaRangeMatches - whether a media range, such as text/* or */*, has the
+// media type
+func mediaRangeMatches(mediaRange, mediaType string) bool {
+	mediaRange, mediaType = strings.ToLower(mediaRange), strings.ToLower(mediaType)
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	return strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, mediaRange[:len(mediaRange)-1])
+}
+
+// suffixType - the media type a media type with a structured syntax suffix is
+// written in, such as application/json for application/vnd.acme.v2+json
+func suffixType(mediaType string) string {
+	i := strings.LastIndexByte(mediaType, '+')
+	slash := strings.IndexByte(mediaType, '/')
+	if i < 0 || slash < 0 || i < slash {
+		return mediaType
+	}
+	return mediaType[:slash+1] + mediaType[i+1:]
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteNegotiation(t *testing.T) {
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(body))
+		}
+	}
+	r := NewRouter()
+	r.Route("GET", "/reports/:id").Produces("application/json").Handle(respond("json"))
+	r.Route("GET", "/reports/:id").Produces("text/csv", "text/plain").Handle(respond("csv"))
+	r.Route("POST", "/reports").Consumes("application/json").Produces("application/json").Handle(respond("json"))
+	r.Route("POST", "/reports").Consumes("text/*").Handle(respond("text"))
+
+	for _, c := range []struct {
+		method, path, accept, contentType, body string
+		code                                    int
+		want, wantType                          string
+	}{
+		{"GET", "/reports/1", "", "", "", 200, "json", "application/json"},
+		{"GET", "/reports/1", "text/csv", "", "", 200, "csv", "text/csv"},
+		{"GET", "/reports/1", "text/*", "", "", 200, "csv", "text/csv"},
+		{"GET", "/reports/1", "text/csv;q=0.5, application/json;q=0.9", "", "", 200, "json", "application/json"},
+		{"GET", "/reports/1", "text/*;q=0.9, text/plain", "", "", 200, "csv", "text/plain"},
+		{"GET", "/reports/1", "*/*;q=0.1, application/json;q=0", "", "", 200, "csv", "text/csv"},
+		{"GET", "/reports/1", "image/png", "", "", 406, "", ""},
+		{"HEAD", "/reports/1", "image/png", "", "", 406, "", ""},
+		{"POST", "/reports", "", "application/json; charset=utf-8", "{}", 200, "json", "application/json"},
+		{"POST", "/reports", "", "text/csv", "a,b", 200, "text", ""},
+		// handlers declaring nothing they produce are not negotiated
+		{"POST", "/reports", "application/json", "text/csv", "a,b", 200, "text", ""},
+		{"POST", "/reports", "image/png", "image/png", "x", 415, "", ""},
+		{"POST", "/reports", "", "", "", 200, "json", "application/json"},
+		{"DELETE", "/reports", "", "", "", 405, "", ""},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, strings.NewReader(c.body))
+		if c.body == "" {
+			req.Body = http.NoBody
+		}
+		if c.accept != "" {
+			req.Header.Set("Accept", c.accept)
+		}
+		if c.contentType != "" {
+			req.Header.Set("Content-Type", c.contentType)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, "%s %s %q %q", c.method, c.path, c.accept, c.contentType)
+		if c.code != 200 {
+			continue
+		}
+		if c.method != "HEAD" {
+			assert.Equal(t, c.want, w.Body.String(), "%s %s %q", c.method, c.path, c.accept)
+		}
+		if c.wantType != "" {
+			assert.Equal(t, c.wantType, w.Header().Get("Content-Type"))
+		}
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	}
+}
+
+func TestRouteMiddleware(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, req *http.Request) {
+				calls = append(calls, name)
+				next(w, req)
+			}
+		}
+	}
+	r := NewRouter()
+	r.Route("GET", "/a").Produces("application/json").Handle(func(w http.ResponseWriter, req *http.Request) {}, mw("json"))
+	r.Route("GET", "/a").Produces("text/html").Handle(func(w http.ResponseWriter, req *http.Request) {}, mw("html"))
+
+	req, _ := http.NewRequest("GET", "/a", nil)
+	req.Header.Set("Accept", "text/html")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, []string{"html"}, calls)
+}
+
+func TestParseAccept(t *testing.T) {
+	assert.Nil(t, parseAccept(""))
+	assert.Equal(t, []mediaRange{{"text/html", 1}, {"application/json", 0.5}},
+		parseAccept("text/html, application/json;q=0.5, ;q=x, text/csv;q=nope"))
+
+	accept := parseAccept("text/*;q=0.3, text/html;q=0.7, */*;q=0.1")
+	assert.Equal(t, 0.7, acceptQuality(accept, "text/html"))
+	assert.Equal(t, 0.3, acceptQuality(accept, "text/csv"))
+	assert.Equal(t, 0.1, acceptQuality(accept, "image/png"))
+}
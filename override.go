@@ -0,0 +1,62 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MethodOverride - Create pre-routing middleware (see Router.Pre) routing POST
+// requests as the method named by their X-HTTP-Method-Override header, or by the
+// _method field of their form, for clients such as HTML forms that can only
+// POST.  Only the methods given can be overridden to, PUT, PATCH and DELETE when
+// none are.  Handlers get the method the request is routed as, and
+// OriginalMethod gets the method the client sent.
+func MethodOverride(methods ...string) Middleware {
+	if len(methods) == 0 {
+		methods = []string{http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	allowed := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		allowed[strings.ToUpper(m)] = true
+	}
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				if method := strings.ToUpper(overrideMethod(r)); allowed[method] {
+					r2 := r.WithContext(context.WithValue(r.Context(), methodKey, r.Method))
+					r2.Method = method
+					r = r2
+				}
+			}
+			next(w, r)
+		}
+	}
+}
+
+// OriginalMethod - Get the method a client sent a request with, before
+// MethodOverride overrode it
+func OriginalMethod(r *http.Request) string {
+	if method, ok := r.Context().Value(methodKey).(string); ok {
+		return method
+	}
+	return r.Method
+}
+
+// overrideMethod - the method a request asks to be routed as, the form only
+// being read for requests with a form body
+func overrideMethod(r *http.Request) string {
+	if method := r.Header.Get("X-HTTP-Method-Override"); method != "" {
+		return method
+	}
+	switch ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct {
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+		return r.PostFormValue("_method")
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodOverride(t *testing.T) {
+	echo := func(w http.ResponseWriter, req *http.Request) {
+		name := req.PostFormValue("name")
+		body, _ := io.ReadAll(req.Body)
+		w.Write([]byte(req.Method + " " + OriginalMethod(req) + " " + name + string(body)))
+	}
+	r := NewRouter()
+	r.Pre(MethodOverride())
+	r.Post("/items/:id", echo)
+	r.Put("/items/:id", echo)
+	r.Delete("/items/:id", echo)
+	r.Get("/items/:id", echo)
+
+	form := "application/x-www-form-urlencoded"
+	for _, c := range []struct {
+		method, contentType, override, body string
+		code                                int
+		want                                string
+	}{
+		{"POST", form, "", "_method=PUT&name=x", 200, "PUT POST x"},
+		{"POST", form, "", "_method=delete", 200, "DELETE POST "},
+		{"POST", "application/json", "PUT", `{"name": "x"}`, 200, `PUT POST {"name": "x"}`},
+		// the json body is not read for a _method field
+		{"POST", "application/json", "", `{"_method": "PUT"}`, 200, `POST POST {"_method": "PUT"}`},
+		// PATCH is overridable, but the route has no PATCH handler
+		{"POST", form, "PATCH", "", 405, ""},
+		// GET is not overridable
+		{"POST", form, "GET", "name=x", 200, "POST POST x"},
+		{"PUT", form, "DELETE", "", 200, "PUT PUT "},
+	} {
+		req, _ := http.NewRequest(c.method, "/items/1", strings.NewReader(c.body))
+		req.Header.Set("Content-Type", c.contentType)
+		if c.override != "" {
+			req.Header.Set("X-HTTP-Method-Override", c.override)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.override+" "+c.body)
+		if c.want != "" {
+			assert.Equal(t, c.want, w.Body.String(), c.override+" "+c.body)
+		}
+	}
+
+	r = NewRouter()
+	r.Pre(MethodOverride("patch"))
+	r.Patch("/items/:id", echo)
+	req, _ := http.NewRequest("POST", "/items/1", http.NoBody)
+	req.Header.Set("X-HTTP-Method-Override", "PATCH")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "PATCH POST ", w.Body.String())
+}
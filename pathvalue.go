@@ -0,0 +1,14 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+//go:build go1.22
+
+package vestigo
+
+import "net/http"
+
+// setPathValue - set a url param of the request, for http.Request.PathValue
+func setPathValue(r *http.Request, name, value string) {
+	r.SetPathValue(name, value)
+}
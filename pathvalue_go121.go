@@ -0,0 +1,13 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+//go:build !go1.22
+
+package vestigo
+
+import "net/http"
+
+// setPathValue - http.Request.PathValue needs Go 1.22, url params are only
+// found with Param before it
+func setPathValue(r *http.Request, name, value string) {}
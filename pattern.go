@@ -0,0 +1,133 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// stdPattern - a pattern of http.ServeMux, translated to router paths
+type stdPattern struct {
+	// method - the method of the pattern, empty for every method
+	method string
+	paths  []string
+	// names - the names of the wildcards of the pattern
+	names []string
+}
+
+// isStdPattern - whether a path is a pattern of http.ServeMux, which has a
+// method or wildcards in braces
+func isStdPattern(path string) bool {
+	return strings.ContainsAny(path, "{ \t")
+}
+
+// parseStdPattern - translate a pattern of http.ServeMux, {name} wildcards
+// becoming url params, {name...} a named match-any and {$} the end of the
+// path.  Patterns ending with a slash match every path under them, as they do
+// with http.ServeMux.
+func parseStdPattern(pattern string) (*stdPattern, error) {
+	p := new(stdPattern)
+	rest := pattern
+	if i := strings.IndexAny(rest, " \t"); i >= 0 {
+		p.method, rest = rest[:i], strings.TrimLeft(rest[i:], " \t")
+		if !methods[p.method] || p.method == http.MethodHead || p.method == http.MethodOptions {
+			return nil, fmt.Errorf("vestigo: pattern %s: method %s can not be routed", pattern, p.method)
+		}
+	}
+	if !strings.HasPrefix(rest, "/") {
+		return nil, fmt.Errorf("vestigo: pattern %s: host patterns are not supported", pattern)
+	}
+
+	path := ""
+	segments := strings.Split(rest[1:], "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		path += "/"
+		if !strings.ContainsAny(segment, "{}") {
+			if strings.ContainsAny(segment, ":*") {
+				return nil, fmt.Errorf("vestigo: pattern %s: ':' and '*' can not be routed", pattern)
+			}
+			path += segment
+			continue
+		}
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			return nil, fmt.Errorf("vestigo: pattern %s: wildcards must be whole segments", pattern)
+		}
+
+		// {name:regexp} variables of gorilla/mux are taken too, the url
+		// param being constrained to the regular expression by add
+		name := segment[1 : len(segment)-1]
+		if j := strings.IndexByte(name, ':'); j >= 0 {
+			name = name[:j]
+		}
+		if name == "$" {
+			if !last {
+				return nil, fmt.Errorf("vestigo: pattern %s: {$} must end the pattern", pattern)
+			}
+			p.paths = []string{path}
+			return p, nil
+		}
+		matchAny := name == segment[1:len(segment)-1] && strings.HasSuffix(name, "...")
+		if matchAny {
+			if !last {
+				return nil, fmt.Errorf("vestigo: pattern %s: %s must end the pattern", pattern, segment)
+			}
+			name = strings.TrimSuffix(name, "...")
+		}
+		if !paramNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("vestigo: pattern %s: invalid wildcard name %q", pattern, name)
+		}
+		for _, n := range p.names {
+			if n == name {
+				return nil, fmt.Errorf("vestigo: pattern %s: duplicate wildcard name %q", pattern, name)
+			}
+		}
+		p.names = append(p.names, name)
+		if matchAny {
+			p.paths = []string{path + "*" + name}
+			return p, nil
+		}
+		path += segment
+	}
+
+	p.paths = []string{path}
+	if strings.HasSuffix(path, "/") {
+		p.paths = append(p.paths, path+"*")
+	}
+	return p, nil
+}
+
+// handleStdPattern - add the handler for the paths and method of a pattern of
+// http.ServeMux, panicking when the pattern is invalid
+func (r *Router) handleStdPattern(pattern string, handler http.HandlerFunc, middleware ...Middleware) {
+	p, err := parseStdPattern(pattern)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(p.names) > 0 {
+		middleware = append([]Middleware{pathValues(p.names)}, middleware...)
+	}
+	for _, path := range p.paths {
+		if p.method == "" {
+			r.handleAll(path, handler, middleware...)
+		} else {
+			r.Add(p.method, path, handler, middleware...)
+		}
+	}
+}
+
+// pathValues - middleware setting the url params named for r.PathValue
+func pathValues(names []string) Middleware {
+	return func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range names {
+				setPathValue(r, name, Param(r, name))
+			}
+			f(w, r)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+//go:build go1.22
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_StdPatterns(t *testing.T) {
+	echo := func(names ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			body := req.Method
+			for _, name := range names {
+				body += " " + name + "=" + req.PathValue(name) + "|" + Param(req, name)
+			}
+			w.Write([]byte(body))
+		}
+	}
+	r := NewRouter()
+	r.HandleFunc("GET /items/{id}", echo("id"))
+	r.HandleFunc("/orgs/{org}/repos/{repo}", echo("org", "repo"))
+	r.Handle("POST /files/{path...}", echo("path"))
+	r.HandleFunc("GET /{$}", echo())
+	r.HandleFunc("GET /static/", echo())
+	r.Get("/users/:id", echo("id"))
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/items/1", 200, "GET id=1|1"},
+		{"POST", "/items/1", 405, ""},
+		{"DELETE", "/orgs/acme/repos/vestigo", 200, "DELETE org=acme|acme repo=vestigo|vestigo"},
+		{"POST", "/files/a/b.txt", 200, "POST path=a/b.txt|a/b.txt"},
+		{"POST", "/files/", 200, "POST path=|"},
+		{"GET", "/", 200, "GET"},
+		{"GET", "/static/", 200, "GET"},
+		{"GET", "/static/css/app.css", 200, "GET"},
+		{"GET", "/static", 404, ""},
+		// routes added with vestigo's syntax only have Param
+		{"GET", "/users/1", 200, "GET id=|1"},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		if c.body != "" {
+			assert.Equal(t, c.body, w.Body.String(), c.method+" "+c.path)
+		}
+	}
+	assert.Equal(t, "/files/*path", r.Match(mustNewRequest("POST", "/files/a")).Template)
+}
+
+func TestParseStdPatternErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"HEAD /items",
+		"FETCH /items",
+		"example.com/items",
+		"/items/{id}/{id}",
+		"/items/{id...}/x",
+		"/items/{$}/x",
+		"/items/x{id}",
+		"/items/{a-b}",
+		"/items/:id/{x}",
+	} {
+		_, err := parseStdPattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+	assert.Panics(t, func() { NewRouter().HandleFunc("GET /items/{id", nil) })
+}
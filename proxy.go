@@ -0,0 +1,207 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProxyOptions - Options of the requests Router.Proxy makes to its target
+type ProxyOptions struct {
+	// Header - headers set on the requests to the target, replacing those of
+	// the client
+	Header http.Header
+	// RemoveHeader - headers of the client not passed on to the target
+	RemoveHeader []string
+	// ResponseHeader - headers set on the responses of the target
+	ResponseHeader http.Header
+	// PreserveHost - pass the Host header of the client on to the target,
+	// instead of the host of the target
+	PreserveHost bool
+	// TrustForwarded - keep the X-Forwarded-* and Forwarded headers of the
+	// client, adding to them, which is only safe behind a proxy setting them.
+	// They are replaced otherwise.
+	TrustForwarded bool
+	// Timeout - the time the target has to answer, no limit when 0
+	Timeout time.Duration
+	// ErrorHandler - answers requests the target could not be reached for,
+	// with 502 Bad Gateway, or 504 Gateway Timeout on timeouts, when nil
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+	// Transport - the transport of the requests, http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// Proxy - Pass the requests of the route added with pattern (as with
+// HandleFunc) on to target, with httputil.ReverseProxy.  The path of target can
+// reference the url params of the route, as in http://users-svc/v2/users/:id,
+// and its wildcard with * (or *name when it is named).  Requests whose params
+// have . or .. segments are refused with 400 Bad Request, so they can not climb
+// out of the path of target.  The query of the request is passed on, after the
+// query of target.  Proxy panics when target is
+// not an absolute url, or references a param without a name.
+func (r *Router) Proxy(pattern, target string, opts *ProxyOptions) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		panic("vestigo: proxy target " + target + " is not an absolute url")
+	}
+	p := &proxy{target: u}
+	if p.params, err = templateParams(u.Path); err != nil {
+		panic("vestigo: proxy target " + target + ": " + err.Error())
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+	p.rp = &httputil.ReverseProxy{
+		Director:       p.direct,
+		Transport:      p.opts.Transport,
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler:   p.opts.ErrorHandler,
+	}
+	if p.rp.ErrorHandler == nil {
+		p.rp.ErrorHandler = proxyError
+	}
+	r.HandleFunc(pattern, p.serve)
+}
+
+// proxy - a route passing its requests on to a target
+type proxy struct {
+	target *url.URL
+	// params - the names of the url params the path of target references
+	params []string
+	opts   ProxyOptions
+	rp     *httputil.ReverseProxy
+}
+
+func (p *proxy) serve(w http.ResponseWriter, r *http.Request) {
+	for _, name := range p.params {
+		if hasDotSegment(Param(r, name)) {
+			// the path to the target would climb out of the path of target
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			return
+		}
+	}
+	if p.opts.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), p.opts.Timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+	p.rp.ServeHTTP(w, r)
+}
+
+// direct - turn the request of the client into the request to the target
+func (p *proxy) direct(out *http.Request) {
+	host, proto := out.Host, "http"
+	if out.TLS != nil {
+		proto = "https"
+	}
+
+	out.URL.Scheme = p.target.Scheme
+	out.URL.Host = p.target.Host
+	out.URL.Path, out.URL.RawPath = expandTemplate(p.target.Path, out), ""
+	out.URL.RawQuery = joinQuery(p.target.RawQuery, clientQuery(out.URL.RawQuery))
+	if !p.opts.PreserveHost {
+		out.Host = ""
+	}
+
+	if !p.opts.TrustForwarded {
+		for _, k := range []string{"Forwarded", "X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto"} {
+			out.Header.Del(k)
+		}
+	}
+	// X-Forwarded-For is added to by httputil.ReverseProxy
+	if out.Header.Get("X-Forwarded-Host") == "" {
+		out.Header.Set("X-Forwarded-Host", host)
+	}
+	if out.Header.Get("X-Forwarded-Proto") == "" {
+		out.Header.Set("X-Forwarded-Proto", proto)
+	}
+	forwarded := "host=" + quoteForwarded(host) + ";proto=" + proto
+	if ip, _, err := net.SplitHostPort(out.RemoteAddr); err == nil {
+		if strings.Contains(ip, ":") {
+			ip = "[" + ip + "]"
+		}
+		forwarded = "for=" + quoteForwarded(ip) + ";" + forwarded
+	}
+	if prior := out.Header.Get("Forwarded"); prior != "" {
+		forwarded = prior + ", " + forwarded
+	}
+	out.Header.Set("Forwarded", forwarded)
+
+	for _, k := range p.opts.RemoveHeader {
+		out.Header.Del(k)
+	}
+	for k, v := range p.opts.Header {
+		out.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+}
+
+// modifyResponse - set the response headers of the options
+func (p *proxy) modifyResponse(res *http.Response) error {
+	for k, v := range p.opts.ResponseHeader {
+		res.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	return nil
+}
+
+// hasDotSegment - whether a path has a . or .. segment
+func hasDotSegment(path string) bool {
+	for _, segment := range strings.FieldsFunc(path, func(c rune) bool { return c == '/' || c == '\\' }) {
+		if segment == "." || segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyError - answer requests the target could not be reached for
+func proxyError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(http.StatusText(status)))
+}
+
+// clientQuery - the query of the request without the url params the router
+// added to it
+func clientQuery(rawQuery string) string {
+	if !strings.Contains(rawQuery, "%3A") {
+		return rawQuery
+	}
+	var kept []string
+	for _, v := range strings.Split(rawQuery, "&") {
+		if !strings.HasPrefix(v, "%3A") {
+			kept = append(kept, v)
+		}
+	}
+	return strings.Join(kept, "&")
+}
+
+// joinQuery - join two raw queries
+func joinQuery(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "&" + b
+}
+
+// quoteForwarded - quote a value of the Forwarded header when it is not a token
+func quoteForwarded(v string) string {
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0) {
+			return `"` + strings.Replace(v, `"`, `\"`, -1) + `"`
+		}
+	}
+	return v
+}
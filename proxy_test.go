@@ -0,0 +1,154 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// proxied - what a backend got from a proxy
+type proxied struct {
+	Method, Host, Path, Query string
+	Header                    http.Header
+}
+
+func proxyBackend() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Header().Set("X-Backend", "users")
+		json.NewEncoder(w).Encode(proxied{r.Method, r.Host, r.URL.Path, r.URL.RawQuery, r.Header})
+	}))
+}
+
+func proxyThrough(r *Router, method, path string, header http.Header) (*httptest.ResponseRecorder, proxied) {
+	req, _ := http.NewRequest(method, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var got proxied
+	json.Unmarshal(w.Body.Bytes(), &got)
+	return w, got
+}
+
+func TestRouter_Proxy(t *testing.T) {
+	backend := proxyBackend()
+	defer backend.Close()
+
+	r := NewRouter()
+	r.Proxy("/users/:id", backend.URL+"/v2/users/:id?source=edge", &ProxyOptions{
+		Header:         http.Header{"X-Service": {"edge"}},
+		RemoveHeader:   []string{"Cookie"},
+		ResponseHeader: http.Header{"X-Proxied": {"true"}},
+	})
+	r.Proxy("/files/*", backend.URL+"/static/*", nil)
+
+	w, got := proxyThrough(r, "PATCH", "http://example.com/users/42?fields=name", http.Header{
+		"Cookie":          {"session=secret"},
+		"X-Forwarded-For": {"6.6.6.6"},
+		"Forwarded":       {"for=6.6.6.6"},
+	})
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "users", w.Header().Get("X-Backend"))
+	assert.Equal(t, "true", w.Header().Get("X-Proxied"))
+	assert.Equal(t, "PATCH", got.Method)
+	assert.Equal(t, backend.Listener.Addr().String(), got.Host)
+	assert.Equal(t, "/v2/users/42", got.Path)
+	assert.Equal(t, "source=edge&fields=name", got.Query, "the url params the router adds are not passed on")
+	assert.Equal(t, "edge", got.Header.Get("X-Service"))
+	assert.Empty(t, got.Header.Get("Cookie"))
+	assert.Equal(t, "10.0.0.1", got.Header.Get("X-Forwarded-For"), "forwarding headers of the client are not trusted")
+	assert.Equal(t, "example.com", got.Header.Get("X-Forwarded-Host"))
+	assert.Equal(t, "http", got.Header.Get("X-Forwarded-Proto"))
+	assert.Equal(t, "for=10.0.0.1;host=example.com;proto=http", got.Header.Get("Forwarded"))
+
+	_, got = proxyThrough(r, "GET", "/files/css/app.css", nil)
+	assert.Equal(t, "/static/css/app.css", got.Path)
+}
+
+func TestRouter_ProxyDotSegments(t *testing.T) {
+	backend := proxyBackend()
+	defer backend.Close()
+
+	r := NewRouter()
+	r.Proxy("/users/:id", backend.URL+"/v2/users/:id", nil)
+	r.Proxy("/files/*", backend.URL+"/static/*", nil)
+	r.Proxy("/a/:user-id/x", backend.URL+"/svc/users/:user-id/x", nil)
+
+	for path, code := range map[string]int{
+		"/a/../x":                   400,
+		"/a/bob/x":                  200,
+		"/files/../../admin/secret": 400,
+		"/files/a/%2E%2E/x":         400,
+		"/files/.%5C..%5Cadmin":     400,
+		"/files/./x":                400,
+		"/users/..":                 400,
+		"/files/a..b/c.":            200,
+		"/users/..42":               200,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code, path)
+	}
+}
+
+func TestRouter_ProxyBadTarget(t *testing.T) {
+	r := NewRouter()
+	assert.Panics(t, func() { r.Proxy("/a/:id", "http://svc/:/x", nil) })
+	assert.Panics(t, func() { r.Proxy("/a/:id", "/svc/:id", nil) })
+}
+
+func TestRouter_ProxyTrustForwarded(t *testing.T) {
+	backend := proxyBackend()
+	defer backend.Close()
+
+	r := NewRouter()
+	r.Proxy("GET /users/{id}", backend.URL+"/users/:id", &ProxyOptions{TrustForwarded: true, PreserveHost: true})
+
+	_, got := proxyThrough(r, "GET", "http://example.com/users/1", http.Header{
+		"X-Forwarded-For":   {"1.2.3.4"},
+		"X-Forwarded-Proto": {"https"},
+		"Forwarded":         {"for=1.2.3.4;proto=https"},
+	})
+	assert.Equal(t, "example.com", got.Host)
+	assert.Equal(t, "/users/1", got.Path)
+	assert.Equal(t, "1.2.3.4, 10.0.0.1", got.Header.Get("X-Forwarded-For"))
+	assert.Equal(t, "https", got.Header.Get("X-Forwarded-Proto"))
+	assert.Equal(t, "for=1.2.3.4;proto=https, for=10.0.0.1;host=example.com;proto=http", got.Header.Get("Forwarded"))
+}
+
+func TestRouter_ProxyErrors(t *testing.T) {
+	backend := proxyBackend()
+	defer backend.Close()
+
+	r := NewRouter()
+	r.Proxy("/slow", backend.URL+"/slow", &ProxyOptions{Timeout: 10 * time.Millisecond})
+	r.Proxy("/down", "http://127.0.0.1:1/down", nil)
+	r.Proxy("/custom", "http://127.0.0.1:1/down", &ProxyOptions{
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	})
+
+	w, _ := proxyThrough(r, "GET", "/slow", nil)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	w, _ = proxyThrough(r, "GET", "/down", nil)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	w, _ = proxyThrough(r, "GET", "/custom", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	assert.Panics(t, func() { r.Proxy("/x", "/relative", nil) })
+}
@@ -0,0 +1,164 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RedirectRule - A redirect route, see Router.Redirect
+type RedirectRule struct {
+	// Method - the method redirected, GET (and so HEAD) when empty
+	Method string `json:"method,omitempty"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	// Code - the status of the redirect, 301 Moved Permanently when 0
+	Code int `json:"code,omitempty"`
+}
+
+// Redirect - Add a route redirecting the requests for fromPattern to
+// toTemplate.  toTemplate can reference the url params and the wildcard of
+// fromPattern, as in /old/:id/*rest to /new/:id/*rest, and be an absolute url.
+// The query of the request is kept, unless toTemplate has a query of its own,
+// which replaces it ("/new?" drops it).  Redirect panics when the rule is
+// invalid, code not being a redirect status or toTemplate referencing params
+// fromPattern does not have.
+func (r *Router) Redirect(method, fromPattern, toTemplate string, code int) {
+	if err := r.LoadRedirects([]RedirectRule{{method, fromPattern, toTemplate, code}}); err != nil {
+		panic(err.Error())
+	}
+}
+
+// LoadRedirects - Add the redirect routes of the rules, as with Redirect.  An
+// error is returned, and no route added, when one of the rules is invalid.
+func (r *Router) LoadRedirects(rules []RedirectRule) error {
+	redirects := make([]*redirect, len(rules))
+	for i, rule := range rules {
+		rd, err := newRedirect(rule)
+		if err != nil {
+			return err
+		}
+		redirects[i] = rd
+	}
+	for i, rule := range rules {
+		method := rule.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		r.Add(method, rule.From, redirects[i].serve)
+	}
+	return nil
+}
+
+// ParseRedirectsJSON - Parse a JSON array of redirect rules, such as
+//
+//	[{"from": "/old/:id/*rest", "to": "/new/:id/*rest", "code": 308}]
+func ParseRedirectsJSON(r io.Reader) ([]RedirectRule, error) {
+	var rules []RedirectRule
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("redirects: %v", err)
+	}
+	return rules, nil
+}
+
+// ParseRedirectsCSV - Parse redirect rules from CSV records of from, to, and
+// optionally the code and the method.  A first record of from,to,... is a header,
+// and records starting with # are comments.
+func ParseRedirectsCSV(r io.Reader) ([]RedirectRule, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("redirects: %v", err)
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "from") {
+		records = records[1:]
+	}
+
+	rules := make([]RedirectRule, len(records))
+	for i, record := range records {
+		if len(record) < 2 || len(record) > 4 {
+			return nil, fmt.Errorf("redirects: record %d: want from, to, code and method, got %d fields", i+1, len(record))
+		}
+		rules[i] = RedirectRule{From: record[0], To: record[1]}
+		if len(record) > 2 && record[2] != "" {
+			if rules[i].Code, err = strconv.Atoi(record[2]); err != nil {
+				return nil, fmt.Errorf("redirects: record %d: invalid code %q", i+1, record[2])
+			}
+		}
+		if len(record) > 3 {
+			rules[i].Method = record[3]
+		}
+	}
+	return rules, nil
+}
+
+// redirect - a redirect route
+type redirect struct {
+	to   *url.URL
+	code int
+	// query - whether to has a query, which replaces the query of requests
+	query bool
+}
+
+// newRedirect - check a redirect rule, and prepare its route
+func newRedirect(rule RedirectRule) (*redirect, error) {
+	rd := &redirect{code: rule.Code}
+	if rd.code == 0 {
+		rd.code = http.StatusMovedPermanently
+	}
+	switch rd.code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, fmt.Errorf("redirect %s: %d is not a redirect status", rule.From, rd.code)
+	}
+	if rule.Method != "" && !validMethod(rule.Method) {
+		return nil, fmt.Errorf("redirect %s: invalid method %q", rule.From, rule.Method)
+	}
+
+	var err error
+	if rd.to, err = url.Parse(rule.To); err != nil {
+		return nil, fmt.Errorf("redirect %s: %v", rule.From, err)
+	}
+	if !strings.HasPrefix(rule.To, "/") && !rd.to.IsAbs() {
+		return nil, fmt.Errorf("redirect %s: %s is neither a path nor an absolute url", rule.From, rule.To)
+	}
+	_, names, err := templateRegexp(rule.From)
+	if err != nil {
+		return nil, fmt.Errorf("redirect: %v", err)
+	}
+	if _, err := templateReplacement(rd.to.Path, names); err != nil {
+		return nil, fmt.Errorf("redirect: %v", err)
+	}
+	rd.query = rd.to.RawQuery != "" || rd.to.ForceQuery
+	return rd, nil
+}
+
+func (rd *redirect) serve(w http.ResponseWriter, r *http.Request) {
+	u := *rd.to
+	u.Path, u.RawPath = expandTemplate(rd.to.Path, r), ""
+	if !rd.to.IsAbs() {
+		// params starting with slashes must not turn the path into a
+		// link to another host, such as //evil.com or /\evil.com
+		u.Path = "/" + strings.TrimLeft(u.Path, "/\\")
+	}
+	if !rd.query {
+		u.RawQuery = clientQuery(r.URL.RawQuery)
+	}
+	u.ForceQuery = false
+	http.Redirect(w, r, u.String(), rd.code)
+}
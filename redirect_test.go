@@ -0,0 +1,114 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Redirect(t *testing.T) {
+	r := NewRouter()
+	r.Redirect("", "/old/:id/*rest", "/new/:id/*rest", 0)
+	r.Redirect("POST", "/legacy/orders/:id", "https://orders.example.com/orders/:id", http.StatusPermanentRedirect)
+	r.Redirect("GET", "/search", "/find?q=all", http.StatusFound)
+	r.Redirect("GET", "/drop", "/dropped?", http.StatusFound)
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		location     string
+	}{
+		{"GET", "/old/1/a/b?x=1&y=2", 301, "/new/1/a/b?x=1&y=2"},
+		{"HEAD", "/old/a%20b/c", 301, "/new/a%20b/c"},
+		{"POST", "/old/1/a", 405, ""},
+		{"POST", "/legacy/orders/7?v=2", 308, "https://orders.example.com/orders/7?v=2"},
+		{"GET", "/search?q=x", 302, "/find?q=all"},
+		{"GET", "/drop?q=x", 302, "/dropped"},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		assert.Equal(t, c.location, w.Header().Get("Location"), c.method+" "+c.path)
+	}
+
+	for _, rule := range []RedirectRule{
+		{From: "/a/:id", To: "/b/:name"},
+		{From: "/a", To: "/b", Code: 200},
+		{From: "/a", To: "b"},
+		{From: "/a", To: "/b", Method: "FETCH"},
+	} {
+		assert.Error(t, r.LoadRedirects([]RedirectRule{rule}), rule.From+" -> "+rule.To)
+	}
+	assert.Panics(t, func() { r.Redirect("GET", "/a/:id", "/b/:name", 301) })
+}
+
+func TestRouter_RedirectStaysOnHost(t *testing.T) {
+	r := NewRouter()
+	r.Redirect("GET", "/old/*rest", "/*rest", 301)
+	r.Redirect("GET", "/ext/*rest", "https://example.com/*rest", 301)
+
+	for path, location := range map[string]string{
+		"/old//evil.com/x":     "/evil.com/x",
+		"/old/%2Fevil.com":     "/evil.com",
+		"/old/%5Cevil.com":     "/evil.com",
+		"/old/%2F%5C/evil.com": "/evil.com",
+		"/old/a//b":            "/a/b",
+		"/ext//x":              "https://example.com//x",
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, 301, w.Code, path)
+		assert.Equal(t, location, w.Header().Get("Location"), path)
+	}
+}
+
+func TestParseRedirects(t *testing.T) {
+	want := []RedirectRule{
+		{From: "/old/:id", To: "/new/:id"},
+		{From: "/blog/*", To: "https://blog.example.com/*", Code: 302},
+		{From: "/orders/:id", To: "/v2/orders/:id", Code: 307, Method: "POST"},
+	}
+
+	rules, err := ParseRedirectsCSV(strings.NewReader(`from,to,code,method
+# moved in 2016
+/old/:id,/new/:id
+/blog/*, https://blog.example.com/*, 302
+/orders/:id,/v2/orders/:id,307,POST
+`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, rules)
+	}
+
+	rules, err = ParseRedirectsJSON(strings.NewReader(`[
+		{"from": "/old/:id", "to": "/new/:id"},
+		{"from": "/blog/*", "to": "https://blog.example.com/*", "code": 302},
+		{"from": "/orders/:id", "to": "/v2/orders/:id", "code": 307, "method": "POST"}
+	]`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, rules)
+	}
+
+	r := NewRouter()
+	if assert.NoError(t, r.LoadRedirects(rules)) {
+		req, _ := http.NewRequest("GET", "/blog/2016/hello", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, "https://blog.example.com/2016/hello", w.Header().Get("Location"))
+	}
+
+	_, err = ParseRedirectsCSV(strings.NewReader("/a,/b,moved\n"))
+	assert.Error(t, err)
+	_, err = ParseRedirectsCSV(strings.NewReader("/a\n"))
+	assert.Error(t, err)
+	_, err = ParseRedirectsJSON(strings.NewReader(`[{"from": "/a", "target": "/b"}]`))
+	assert.Error(t, err)
+}
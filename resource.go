@@ -4,33 +4,45 @@
 
 package vestigo
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 // resource - internal structure for specifying which handlers belong to a particular route
 type resource struct {
-	Cors           *CorsAccessControl
-	Connect        http.HandlerFunc
-	Delete         http.HandlerFunc
-	Get            http.HandlerFunc
-	Patch          http.HandlerFunc
-	Post           http.HandlerFunc
-	Put            http.HandlerFunc
-	Trace          http.HandlerFunc
-	Head           http.HandlerFunc
-	allowedMethods string
+	Cors       *CorsAccessControl
+	MethodCors map[string]*CorsAccessControl
+	Connect    http.HandlerFunc
+	Delete     http.HandlerFunc
+	Get        http.HandlerFunc
+	Patch      http.HandlerFunc
+	Post       http.HandlerFunc
+	Put        http.HandlerFunc
+	Trace      http.HandlerFunc
+	Head       http.HandlerFunc
+	methods    methodSet
+	// options - answers OPTIONS requests instead of the router, which only
+	// mounts set, to pass them on
+	options http.HandlerFunc
 }
 
 // newResource - create a new resource, and give it sane default values
 func newResource() *resource {
 	return &resource{
-		Cors:           new(CorsAccessControl),
-		allowedMethods: "",
+		Cors: new(CorsAccessControl),
 	}
 }
 
 // CopyTo - Copy the Resource to another Resource passed in by reference
 func (h *resource) CopyTo(v *resource) {
 	*v.Cors = *h.Cors
+	if h.MethodCors != nil {
+		v.MethodCors = make(map[string]*CorsAccessControl, len(h.MethodCors))
+		for k, c := range h.MethodCors {
+			v.MethodCors[k] = c
+		}
+	}
 	v.Get = h.Get
 	v.Connect = h.Connect
 	v.Delete = h.Delete
@@ -39,21 +51,46 @@ func (h *resource) CopyTo(v *resource) {
 	v.Post = h.Post
 	v.Put = h.Put
 	v.Trace = h.Trace
-	v.allowedMethods = h.allowedMethods
+	v.options = h.options
+	v.methods = h.methods
+}
+
+// mergeCors - Merge a CORS policy into the resource.  Policies set with the
+// "CORS <method>" pseudo method only apply to that method on the resource.
+func (h *resource) mergeCors(method string, cors *CorsAccessControl) {
+	if m := strings.TrimPrefix(method, "CORS "); m != method {
+		if h.MethodCors == nil {
+			h.MethodCors = make(map[string]*CorsAccessControl)
+		}
+		if h.MethodCors[m] == nil {
+			h.MethodCors[m] = new(CorsAccessControl)
+		}
+		h.MethodCors[m] = h.MethodCors[m].Merge(cors)
+		return
+	}
+	h.Cors = h.Cors.Merge(cors)
+}
+
+// corsFor - Get the CORS policy of the resource for a method, which is the
+// resource policy with any policy specific to the method merged on top
+func (h *resource) corsFor(method string) *CorsAccessControl {
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if c, ok := h.MethodCors[method]; ok {
+		return h.Cors.Merge(c)
+	}
+	return h.Cors
 }
 
 // addToAllowedMethods - Add a method to the allowed methods for this route
 func (h *resource) addToAllowedMethods(method string) {
-	if h.allowedMethods == "" {
-		h.allowedMethods = method
-	} else {
-		h.allowedMethods = h.allowedMethods + ", " + method
-	}
+	h.methods |= methodBit(method)
 }
 
 // Clean - Clean up allowed methods based on funcs
 func (h *resource) Clean() {
-	h.allowedMethods = ""
+	h.methods = 0
 	hasOneMethod := false
 	if h.Get != nil {
 		h.addToAllowedMethods(http.MethodGet)
@@ -82,9 +119,11 @@ func (h *resource) Clean() {
 		hasOneMethod = true
 	}
 	if hasOneMethod && AllowTrace {
-		h.addToAllowedMethods(http.MethodTrace)
 		h.Trace = traceHandler
 	}
+	if h.Trace != nil {
+		h.addToAllowedMethods(http.MethodTrace)
+	}
 }
 
 // AddMethodHandler - Add a method/handler pair to the resource structure
@@ -136,38 +175,109 @@ func (h *resource) AddMethodHandler(method string, handler http.HandlerFunc) {
 }
 
 // GetMethodHandler - Get a method/handler pair from the resource structure
-func (h *resource) GetMethodHandler(method string) (http.HandlerFunc, string) {
+func (h *resource) GetMethodHandler(method string) (http.HandlerFunc, methodSet) {
 	l := len(method)
 	firstChar := method[0]
 	secondChar := method[1]
 	if l == 3 {
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x4745 {
-			return h.Get, h.allowedMethods
+			return h.Get, h.methods
 		}
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x5055 {
-			return h.Put, h.allowedMethods
+			return h.Put, h.methods
 		}
 	} else if l == 4 {
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x504f {
-			return h.Post, h.allowedMethods
+			return h.Post, h.methods
 		}
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x4845 {
-			return h.Head, h.allowedMethods
+			return h.Head, h.methods
 		}
 	} else if l == 5 {
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x5452 {
-			return h.Trace, h.allowedMethods
+			return h.Trace, h.methods
 		}
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x5041 {
-			return h.Patch, h.allowedMethods
+			return h.Patch, h.methods
 		}
 	} else if l >= 6 {
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x4445 {
-			return h.Delete, h.allowedMethods
+			return h.Delete, h.methods
 		}
 		if uint16(firstChar)<<8|uint16(secondChar) == 0x434f {
-			return h.Connect, h.allowedMethods
+			return h.Connect, h.methods
+		}
+		if uint16(firstChar)<<8|uint16(secondChar) == 0x4f50 {
+			return h.options, h.methods
+		}
+	}
+	return nil, h.methods
+}
+
+// methodSet - a set of the methods of a resource, one bit per method
+type methodSet uint8
+
+// setMethods - the methods of a methodSet, in the order Allow headers list them
+var setMethods = [...]string{
+	http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost,
+	http.MethodPatch, http.MethodDelete, http.MethodConnect, http.MethodTrace,
+}
+
+// methodSetStrings, methodSetLists - every methodSet as an Allow header and as
+// a list, worked out once so neither has to be built per request
+var (
+	methodSetStrings [1 << len(setMethods)]string
+	methodSetLists   [1 << len(setMethods)][]string
+)
+
+func init() {
+	for s := range methodSetLists {
+		for i, method := range setMethods {
+			if s&(1<<uint(i)) != 0 {
+				methodSetLists[s] = append(methodSetLists[s], method)
+			}
 		}
+		methodSetStrings[s] = strings.Join(methodSetLists[s], ", ")
+	}
+}
+
+// methodIndex - the position of a method in setMethods, -1 for methods that
+// are not there
+func methodIndex(method string) int {
+	for i, m := range setMethods {
+		if m == method {
+			return i
+		}
+	}
+	return -1
+}
+
+// methodBit - the bit of a method in a methodSet, 0 for methods that have none
+func methodBit(method string) methodSet {
+	if i := methodIndex(method); i >= 0 {
+		return 1 << uint(i)
 	}
-	return nil, h.allowedMethods
+	return 0
+}
+
+// has - whether the method is in the set
+func (s methodSet) has(method string) bool {
+	bit := methodBit(method)
+	return bit != 0 && s&bit != 0
+}
+
+// String - the methods of the set as an Allow header
+func (s methodSet) String() string {
+	return methodSetStrings[s]
+}
+
+// list - the methods of the set, which is shared and should not be modified
+func (s methodSet) list() []string {
+	return methodSetLists[s]
+}
+
+// copyList - the methods of the set, in a list of their own, for callers
+// outside of the package to do as they please with
+func (s methodSet) copyList() []string {
+	return append([]string(nil), methodSetLists[s]...)
 }
@@ -0,0 +1,214 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// paramNameRegexp - names of params usable in rewrite templates
+var paramNameRegexp = regexp.MustCompile(`^\w+$`)
+
+// RewriteRule - A rule rewriting request paths that match it, see NewRegexpRewrite
+// and NewTemplateRewrite.  Rules are applied as pre-routing middleware with Rewrite.
+type RewriteRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// NewRegexpRewrite - Create a rule rewriting paths matching the regular expression
+// pattern to replacement, which can reference the groups captured by pattern with
+// $1 or ${name} as in regexp.Regexp.Expand.
+func NewRegexpRewrite(pattern, replacement string) (*RewriteRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &RewriteRule{re: re, replacement: replacement}, nil
+}
+
+// NewTemplateRewrite - Create a rule rewriting paths matching the route template
+// from to the route template to.  Params and the wildcard captured by from can be
+// used in to, for example "/legacy/users/:id/*" to "/users/:id/*".  A wildcard
+// can be named ("*rest") to tell several of them apart.
+func NewTemplateRewrite(from, to string) (*RewriteRule, error) {
+	pattern, names, err := templateRegexp(from)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: %v", err)
+	}
+	replacement, err := templateReplacement(to, names)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: %v", err)
+	}
+	return NewRegexpRewrite(pattern, replacement)
+}
+
+// Rewrite - Create pre-routing middleware (see Router.Pre) rewriting the request
+// path with the first of the rules that matches it.
+func Rewrite(rules ...*RewriteRule) Middleware {
+	return RewritePath(func(path string) string {
+		for _, rule := range rules {
+			if m := rule.re.FindStringSubmatchIndex(path); m != nil {
+				return string(rule.re.ExpandString(nil, rule.replacement, path, m))
+			}
+		}
+		return path
+	})
+}
+
+// RewritePath - Create pre-routing middleware (see Router.Pre) rewriting the
+// request path with f, for example RewritePath(strings.ToLower).
+func RewritePath(f func(path string) string) Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if path := f(r.URL.Path); path != r.URL.Path {
+				r = withPath(r, path)
+			}
+			next(w, r)
+		}
+	}
+}
+
+// withPath - shallow copy the request, with a new url path
+func withPath(r *http.Request, path string) *http.Request {
+	r2 := new(http.Request)
+	*r2 = *r
+	u := *r.URL
+	u.Path = path
+	u.RawPath = ""
+	r2.URL = &u
+	return r2
+}
+
+// templateRegexp - translate a route template into an anchored regular
+// expression capturing its params and wildcard, with the names captured
+func templateRegexp(template string) (string, []string, error) {
+	var (
+		pattern = "^"
+		names   []string
+	)
+	for i, l := 0, len(template); i < l; i++ {
+		switch template[i] {
+		case ':':
+			j := i + 1
+			for ; i < l && template[i] != '/'; i++ {
+			}
+			if j == i {
+				return "", nil, fmt.Errorf("template %s: param without a name", template)
+			}
+			names = append(names, template[j:i])
+			pattern += "(?P<" + template[j:i] + ">[^/]+)"
+			i--
+		case '*':
+			name := template[i+1:]
+			if name == "" {
+				name = "_name"
+			}
+			names = append(names, name)
+			pattern += "(?P<" + name + ">.*)"
+			i = l
+		default:
+			pattern += regexp.QuoteMeta(template[i : i+1])
+		}
+	}
+	for _, name := range names {
+		if !paramNameRegexp.MatchString(name) {
+			return "", nil, fmt.Errorf("template %s: invalid param name %q", template, name)
+		}
+	}
+	return pattern + "$", names, nil
+}
+
+// templateReplacement - translate a route template into a regexp replacement,
+// referencing the params and wildcard captured by the template it replaces
+func templateReplacement(template string, names []string) (string, error) {
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	replacement := ""
+	for i, l := 0, len(template); i < l; i++ {
+		switch template[i] {
+		case ':', '*':
+			j := i + 1
+			for ; i < l && template[i] != '/'; i++ {
+			}
+			name := template[j:i]
+			if template[j-1] == '*' {
+				name = template[j:]
+				i = l
+				if name == "" {
+					name = "_name"
+				}
+			}
+			if !known[name] {
+				return "", fmt.Errorf("template %s: %q is not captured", template, name)
+			}
+			replacement += "${" + name + "}"
+			i--
+		default:
+			replacement += strings.Replace(template[i:i+1], "$", "$$", -1)
+		}
+	}
+	return replacement, nil
+}
+
+// expandTemplate - expand a route template with the url params of the request,
+// the params and wildcard it references being replaced with their values
+func expandTemplate(template string, r *http.Request) string {
+	var path strings.Builder
+	scanTemplate(template, func(literal string) {
+		path.WriteString(literal)
+	}, func(name string) {
+		path.WriteString(Param(r, name))
+	})
+	return path.String()
+}
+
+// templateParams - the names of the url params a template references, as
+// expandTemplate reads them, erroring on params without a name
+func templateParams(template string) ([]string, error) {
+	var names []string
+	scanTemplate(template, func(string) {}, func(name string) {
+		names = append(names, name)
+	})
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("template %s: param without a name", template)
+		}
+	}
+	return names, nil
+}
+
+// scanTemplate - split a route template into its literal parts and the names
+// of the params it references, a param running up to the next slash and a
+// wildcard to the end of the template
+func scanTemplate(template string, literal func(string), param func(name string)) {
+	start := 0
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case ':':
+			literal(template[start:i])
+			j := i + 1
+			for i = j; i < len(template) && template[i] != '/'; i++ {
+			}
+			param(template[j:i])
+			start = i
+			i--
+		case '*':
+			literal(template[start:i])
+			name := template[i+1:]
+			if name == "" {
+				name = "_name"
+			}
+			param(name)
+			return
+		}
+	}
+	literal(template[start:])
+}
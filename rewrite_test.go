@@ -0,0 +1,89 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteRules(t *testing.T) {
+	legacy, err := NewTemplateRewrite("/legacy/users/:id/*rest", "/users/:id/*rest")
+	assert.NoError(t, err)
+	locale, err := NewRegexpRewrite(`^/(en|fr)(/.*)$`, "$2")
+	assert.NoError(t, err)
+
+	r := NewRouter()
+	r.Pre(RewritePath(strings.ToLower), Rewrite(legacy, locale))
+	r.Get("/users/:id/*", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.URL.Path + " " + Param(req, "id") + " " + Param(req, "_name")))
+	})
+
+	for path, body := range map[string]string{
+		"/legacy/users/1/repos/vestigo": "/users/1/repos/vestigo 1 repos/vestigo",
+		"/FR/Users/2/Keys":              "/users/2/keys 2 keys",
+		"/users/3/x":                    "/users/3/x 3 x",
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, body, w.Body.String(), path)
+		assert.Equal(t, path, req.URL.Path, "the original request should be left alone")
+	}
+}
+
+func TestRewriteRuleErrors(t *testing.T) {
+	for from, to := range map[string]string{
+		"/users/:id":   "/people/:name",
+		"/users/:":     "/people",
+		"/files/*":     "/static/*path",
+		"/a/:id-x/:id": "/b",
+	} {
+		_, err := NewTemplateRewrite(from, to)
+		assert.Error(t, err, from+" -> "+to)
+	}
+	_, err := NewRegexpRewrite("(", "")
+	assert.Error(t, err)
+}
+
+func TestRouterPreShortCircuit(t *testing.T) {
+	var calls []string
+	r := NewRouter()
+	r.Use(func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			calls = append(calls, "use")
+			f(w, req)
+		}
+	})
+	r.Pre(func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			calls = append(calls, "pre")
+			if req.URL.Path == "/maintenance" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			f(w, req)
+		}
+	})
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) {
+		calls = append(calls, "handler")
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, []string{"pre", "use", "handler"}, calls)
+
+	calls = nil
+	req, _ = http.NewRequest("GET", "/maintenance", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, []string{"pre"}, calls)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
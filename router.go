@@ -7,7 +7,10 @@ package vestigo
 
 import (
 	"net/http"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -27,8 +30,29 @@ type Middleware func(http.HandlerFunc) http.HandlerFunc
 
 // Router - The main vestigo router data structure
 type Router struct {
-	root       *node
-	globalCors *CorsAccessControl
+	// fallbackHits - first, to be aligned for atomic access on 32 bit platforms
+	fallbackHits uint64
+	fallback     http.HandlerFunc
+	root         *node
+	globalCors   *CorsAccessControl
+	// cors - the *corsState in effect, swapped whole under corsMu
+	cors             atomic.Value
+	corsMu           sync.Mutex
+	corsRejectStatus int
+	onCorsReject     func(*http.Request, *CorsRejection)
+	templates        map[string]bool
+	names            map[string]string
+	metadata         map[string]map[string]interface{}
+	middleware       []Middleware
+	chain            http.HandlerFunc
+	preMiddleware    []Middleware
+	pre              http.HandlerFunc
+	compiled         bool
+	statics          map[string]*node
+	matcher          Matcher
+	matcherNodes     []*node
+	negotiators      map[negotiationKey]*negotiator
+	versioning       *VersionOptions
 }
 
 // NewRouter - Create a new vestigo router
@@ -37,22 +61,68 @@ func NewRouter() *Router {
 		root: &node{
 			resource: newResource(),
 		},
+		templates: make(map[string]bool),
+		names:     make(map[string]string),
+		metadata:  make(map[string]map[string]interface{}),
 	}
 }
 
-// GetMatchedPathTemplate - get the path template from the url in the request
+// SetName - Name the route with the "path" template, the name is then part of
+// the RouteMatch of requests matching the route.
+func (r *Router) SetName(path, name string) {
+	r.mustNotBeCompiled()
+	r.names[path] = name
+}
+
+// SetMetadata - Attach a metadata value to the route with the "path" template,
+// the metadata is then part of the RouteMatch of requests matching the route.
+func (r *Router) SetMetadata(path, key string, value interface{}) {
+	r.mustNotBeCompiled()
+	if r.metadata[path] == nil {
+		r.metadata[path] = make(map[string]interface{})
+	}
+	r.metadata[path][key] = value
+}
+
+// GetMatchedPathTemplate - get the path template from the url in the request,
+// which within a router mounted in this one is the template of the route of
+// the mount, rather than the one of the mounted router
 func (r *Router) GetMatchedPathTemplate(req *http.Request) string {
-	p, _ := r.find(req)
-	return p
+	var m *RouteMatch
+	if rc, _ := req.Context().Value(routerKey{r}).(*routeContext); rc != nil {
+		m = rc.routeMatch()
+	}
+	if m == nil {
+		m = r.Match(req)
+	}
+	if m != nil {
+		return m.Template
+	}
+	return ""
 }
 
 // SetGlobalCors - Settings for Global Cors Options.  This takes a *CorsAccessControl
 // policy, and will apply said policy to every resource.  If this is not set on the
 // router, CORS functionality is turned off.
 func (r *Router) SetGlobalCors(c *CorsAccessControl) {
+	r.mustNotBeCompiled()
 	r.globalCors = c
 }
 
+// SetCorsRejectStatus - Set the status code written when a CORS preflight request
+// is refused, for example http.StatusForbidden.  By default a refused preflight
+// is answered with a 200 that carries no Access-Control-Allow-* headers.
+func (r *Router) SetCorsRejectStatus(status int) {
+	r.corsRejectStatus = status
+}
+
+// OnCorsReject - Register a callback that is called every time a CORS preflight
+// request is refused, with the reason it was refused and the route template it
+// was refused for.  This is useful for logging and counting rejections.
+func (r *Router) OnCorsReject(f func(req *http.Request, rejection *CorsRejection)) {
+	r.onCorsReject = f
+}
+
 // SetCors - Set per resource Cors Policy.  The CorsAccessControl policy passed in
 // will map to the policy that is validated against the "path" resource.  This policy
 // will be merged with the global policy, and values will be deduplicated if there are
@@ -61,10 +131,81 @@ func (r *Router) SetCors(path string, c *CorsAccessControl) {
 	r.addWithCors("CORS", path, nil, c)
 }
 
+// SetMethodCors - Set a per resource, per method Cors Policy.  The CorsAccessControl
+// policy passed in is only used for requests of "method" against the "path" resource,
+// and for preflight requests asking about "method".  This policy is merged on top of
+// the merged global and resource policies.
+func (r *Router) SetMethodCors(method, path string, c *CorsAccessControl) {
+	if !validMethod(method) {
+		panic("invalid method")
+	}
+	r.addWithCors("CORS "+method, path, nil, c)
+}
+
+// Use - Add router wide middleware.  Unlike the middleware given when adding a
+// route, router wide middleware wraps everything the router dispatches, including
+// the not found, method not allowed and OPTIONS responses the router generates.
+// Middleware can tell these apart from matched routes with Matched.  Router wide
+// middleware runs before (outside of) the middleware of the matched route.
+func (r *Router) Use(middleware ...Middleware) {
+	r.mustNotBeCompiled()
+	r.middleware = append(r.middleware, middleware...)
+	r.chain = buildChain(r.serveRoute, r.middleware...)
+}
+
+// Pre - Add pre-routing middleware, which runs before the router looks the
+// request up in the tree.  Pre-routing middleware can rewrite the request, for
+// example its path (see Rewrite), by calling the next handler with a modified
+// request, or answer the request itself by not calling the next handler at all.
+func (r *Router) Pre(middleware ...Middleware) {
+	r.mustNotBeCompiled()
+	r.preMiddleware = append(r.preMiddleware, middleware...)
+	r.pre = buildChain(r.dispatch, r.preMiddleware...)
+}
+
+// Fallback - Serve the requests that match no route with h instead of the not
+// found handler, such as the http.ServeMux of an application moving to vestigo
+// route by route.  Only true misses fall back: requests for a route without a
+// handler for their method are still answered with 405 Method Not Allowed.  The
+// fallback is the router's own, a router mounted within it falling back (or not)
+// on its own, and FallbackHits counts the requests it served.
+func (r *Router) Fallback(h http.Handler) {
+	r.mustNotBeCompiled()
+	r.fallback = func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddUint64(&r.fallbackHits, 1)
+		h.ServeHTTP(w, req)
+	}
+}
+
+// FallbackHits - The number of requests served by the fallback handler, to
+// follow how many requests are still left to migrate
+func (r *Router) FallbackHits() uint64 {
+	return atomic.LoadUint64(&r.fallbackHits)
+}
+
+// miss - the handler of requests that match no route
+func (r *Router) miss() http.HandlerFunc {
+	if r.fallback != nil {
+		return r.fallback
+	}
+	return notFoundHandler
+}
+
 // ServeHTTP - implementation of a http.Handler, making Router a http.Handler
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	h := r.Find(req)
-	h(w, req)
+	if r.pre != nil {
+		r.pre(w, req)
+		return
+	}
+	r.dispatch(w, req)
+}
+
+// dispatch - find the handler for the request, and serve the request with it
+func (r *Router) dispatch(w http.ResponseWriter, req *http.Request) {
+	rc := r.newRouteContext(req)
+	rc.Context = req.Context()
+	r.addParams(req, rc)
+	r.serveContext(w, req.WithContext(rc))
 }
 
 // Get - Helper method to add HTTP GET Method to router
@@ -102,23 +243,33 @@ func (r *Router) Trace(path string, handler http.HandlerFunc, middleware ...Midd
 	r.Add(http.MethodTrace, path, handler, middleware...)
 }
 
-// Handle - Helper method to add all HTTP Methods to router
+// Handle - Helper method to add all HTTP Methods to router.  The path can also be
+// a pattern of http.ServeMux, see HandleFunc.
 func (r *Router) Handle(path string, handler http.Handler, middleware ...Middleware) {
-	for k := range methods {
-		if k == http.MethodHead || k == http.MethodOptions || k == http.MethodTrace {
-			continue
-		}
-		r.Add(k, path, handler.ServeHTTP, middleware...)
-	}
+	r.HandleFunc(path, handler.ServeHTTP, middleware...)
 }
 
-// HandleFunc - Helper method to add all HTTP Methods to router
+// HandleFunc - Helper method to add all HTTP Methods to router.  The path can
+// also be a pattern in the syntax of the http.ServeMux of Go 1.22, such as
+// "GET /items/{id}", "/files/{path...}" or "/{$}", the handler then only being
+// added for the method of the pattern when it has one, and getting the wildcards
+// of the pattern with r.PathValue as well as Param.
 func (r *Router) HandleFunc(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	if isStdPattern(path) {
+		r.handleStdPattern(path, handler, middleware...)
+		return
+	}
+	r.handleAll(path, handler, middleware...)
+}
+
+// handleAll - add the handler for every method but HEAD, which GET gives,
+// OPTIONS and TRACE, which the router answers
+func (r *Router) handleAll(path string, handler http.HandlerFunc, middleware ...Middleware) {
 	for k := range methods {
 		if k == http.MethodHead || k == http.MethodOptions || k == http.MethodTrace {
 			continue
 		}
-		r.Add(k, path, handler.ServeHTTP, middleware...)
+		r.Add(k, path, handler, middleware...)
 	}
 }
 
@@ -134,6 +285,26 @@ func (r *Router) Add(method, path string, h http.HandlerFunc, middleware ...Midd
 
 // Add - Add a method/handler combination to the router
 func (r *Router) add(method, path string, h http.HandlerFunc, cors *CorsAccessControl, middleware ...Middleware) {
+	r.mustNotBeCompiled()
+	if r.matcher != nil {
+		panic("vestigo: routes can not be added once a matcher is set")
+	}
+	var constraints map[string]*regexp.Regexp
+	pattern := path
+	if strings.ContainsAny(path, "{}") {
+		var err error
+		if path, constraints, err = parseVarPattern(path); err != nil {
+			panic(err.Error())
+		}
+	}
+	template := path
+	if !isCorsMethod(method) {
+		if n := r.root.findNode(strippedPath(path)); n != nil && n.conflicts(method, template, constraints) {
+			panic("vestigo: route " + method + " " + pattern + " conflicts with " + n.templateFor(method) +
+				", added with other param names or constraints")
+		}
+		r.templates[template] = true
+	}
 	h = buildChain(h, middleware...)
 	pnames := make(pNames)
 	pnames[method] = []string{}
@@ -152,18 +323,39 @@ func (r *Router) add(method, path string, h http.HandlerFunc, cors *CorsAccessCo
 
 			if i == l {
 				r.insert(method, path[:i], h, ptype, pnames, cors)
+				r.setRoute(method, path[:i], template, constraints)
 				return
 			}
 			r.insert(method, path[:i], nil, ptype, pnames, cors)
 		} else if path[i] == '*' {
 			r.insert(method, path[:i], nil, stype, nil, cors)
-			pnames[method] = append(pnames[method], "_name")
+			// the wildcard can be named, as in /files/*path
+			name := path[i+1:]
+			if name == "" {
+				name = "_name"
+			}
+			pnames[method] = append(pnames[method], name)
 			r.insert(method, path[:i+1], h, mtype, pnames, cors)
+			r.setRoute(method, path[:i+1], template, constraints)
 			return
 		}
 	}
 
 	r.insert(method, path, h, stype, pnames, cors)
+	r.setRoute(method, path, template, constraints)
+}
+
+// setRoute - record the template and the param constraints of a route on the
+// node it was inserted at, the path being the template with its param names
+// stripped
+func (r *Router) setRoute(method, path, template string, constraints map[string]*regexp.Regexp) {
+	if isCorsMethod(method) {
+		return
+	}
+	if n := r.root.findNode(path); n != nil {
+		n.setTemplate(method, template)
+		n.setConstraints(method, constraints)
+	}
 }
 
 // Find - Find A route within the router tree
@@ -172,23 +364,198 @@ func (r *Router) Find(req *http.Request) (h http.HandlerFunc) {
 	return
 }
 
-func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
-	// get tree base node from the router
-	cn := r.root
+// Match - Match the request against the routes of the router, without
+// dispatching it, and without adding the url params to it.  nil is returned when
+// the request path does not match any route.
+func (r *Router) Match(req *http.Request) *RouteMatch {
+	storage := paramStoragePool.Get().(*[maxParams]string)
+	var rc routeContext
+	rc.values = r.resolve(&rc, req, storage[:0])
+	m := rc.routeMatch()
+	*storage = [maxParams]string{}
+	paramStoragePool.Put(storage)
+	return m
+}
 
-	h = notFoundHandler
+// Lookup - Resolve a method and path against the routes of the router, without
+// an http.Request and without any side effects.  The handler is the one added for
+// the method, nil if the route has none, and is not wrapped with the router wide
+// middleware or CORS handling.  An empty template is returned when the path does
+// not match any route.
+func (r *Router) Lookup(method, path string) (handler http.HandlerFunc, params map[string]string, template string, allowed []string) {
+	if !validMethod(method) {
+		return nil, nil, "", nil
+	}
+	storage := paramStoragePool.Get().(*[maxParams]string)
+	if cn, values, methods := r.match(path, storage[:0]); cn != nil && methods != 0 {
+		describe := method
+		if methods.has(method) || method == http.MethodOptions {
+			// as resolve does, OPTIONS is answered by the handler of a
+			// mount when the route has one
+			handler, _ = cn.resource.GetMethodHandler(method)
+		} else if cn.constraints != nil {
+			// describe the route by one whose constraints are met
+			describe = methods.list()[0]
+		}
+		params, template, allowed = paramMap(cn.pnamesFor(describe), values), cn.templateFor(describe), methods.copyList()
+	}
+	*storage = [maxParams]string{}
+	paramStoragePool.Put(storage)
+	return
+}
+
+// find - find the route of the request, adding its url params to the request,
+// and return the route template and a handler serving the request, which can be
+// called at any time
+func (r *Router) find(req *http.Request) (string, http.HandlerFunc) {
+	rc := r.newRouteContext(req)
+	r.addParams(req, rc)
+	return rc.template, func(w http.ResponseWriter, req *http.Request) {
+		c := *rc
+		c.Context = req.Context()
+		r.serveContext(w, req.WithContext(&c))
+	}
+}
 
+// resolve - resolve the method and path of the request against the router tree
+// into the routeContext, choosing the handler that answers the request, and
+// return the url param values, appended to values, which the caller keeps
+func (r *Router) resolve(rc *routeContext, req *http.Request, values []string) (found []string) {
+	rc.router = r
 	if !validMethod(req.Method) {
 		// if the method is completely invalid
-		h = methodNotAllowedHandler(cn.resource.allowedMethods)
+		rc.handler = methodNotAllowedHandler(r.root.resource.methods.String())
+		return nil
+	}
+
+	cn, found, allowed := r.match(req.URL.Path, values)
+	if cn == nil {
+		rc.handler = r.miss()
+		return nil
+	}
+
+	isOptions := uint16(req.Method[0])<<8|uint16(req.Method[1]) == 0x4f50
+	if !isOptions && allowed.has(req.Method) {
+		if cr := r.corsState().compiledRoute(cn, req.Method); cr != nil {
+			rc.node, rc.method, rc.template = cn, req.Method, cr.template
+			rc.methodAllowed, rc.allowed = true, allowed
+			rc.handler, rc.compiled = cr.handler, true
+			return
+		}
+	}
+
+	// Found route, check if method is applicable
+	theHandler, _ := cn.resource.GetMethodHandler(req.Method)
+	if isOptions && allowed == 0 || !isOptions && !allowed.has(req.Method) {
+		// the url params break the constraints of the route of the method
+		theHandler = nil
+	}
+	if allowed != 0 {
+		rc.node = cn
+		rc.method = req.Method
+		if isOptions && theHandler == nil {
+			// a preflight names the method it is asking about, use that
+			// method's param names and CORS policy to describe the route
+			rc.method = req.Header.Get("Access-Control-Request-Method")
+		}
+		rc.template = cn.templateFor(rc.method)
+		if !allowed.has(rc.method) {
+			// describe the route by one whose constraints are met
+			rc.template = cn.templateFor(allowed.list()[0])
+		}
+		rc.methodAllowed = theHandler != nil
+		rc.allowed = allowed
+	}
+
+	switch {
+	case theHandler != nil:
+		// issue 23 - nodes without handlers of their own still have a HEAD
+		// handler, which answers not found
+		rc.handler = theHandler
+	case isOptions:
+		rc.handler = optionsHandler(r, cn.resource, allowed, rc.template)
+	case allowed != 0:
+		// route is valid, but method is not allowed, 405
+		rc.handler = methodNotAllowedHandler(allowed.String())
+	default:
+		rc.handler = r.miss()
+	}
+	return found
+}
+
+// addParams - add the url params of the matched route to the request query
+func (r *Router) addParams(req *http.Request, rc *routeContext) {
+	if rc.node != nil && rc.methodAllowed {
+		addParams(req, rc.node.pnamesFor(req.Method), rc.values)
+	}
+}
+
+// serveContext - serve a request carrying its routeContext, through the router
+// wide middleware
+func (r *Router) serveContext(w http.ResponseWriter, req *http.Request) {
+	if r.chain != nil {
+		r.chain(w, req)
 		return
 	}
+	r.serveRoute(w, req)
+}
+
+// serveRoute - serve a request with the handler of its routeContext, applying
+// the CORS policy of the route to matched requests
+func (r *Router) serveRoute(w http.ResponseWriter, req *http.Request) {
+	rc := routeContextFrom(req)
+	if rc == nil {
+		// router wide middleware dropped the request context
+		rc = r.newRouteContext(req)
+	}
+	if rc.node != nil && rc.methodAllowed && !rc.compiled {
+		// compiled routes apply the CORS policy themselves
+		r.corsFlight(w, req, rc)
+	}
+	rc.handler(w, req)
+}
+
+// corsFlight - set the CORS headers of an actual, not preflight, request
+func (r *Router) corsFlight(w http.ResponseWriter, req *http.Request, rc *routeContext) {
+	if r.globalCors == nil {
+		// without a global policy every merged policy is empty
+		if c := r.corsState().config; c == nil || c.Global == nil {
+			return
+		}
+	}
+	res := rc.node.resource
+	header, _ := evaluateCors(r.corsPolicy(res, rc.template, req.Method), rc.allowed, req, false)
+	setHeaders(w.Header(), header)
+}
+
+// lookup - walk the router tree for the path, returning the node of the
+// resource found and the url param values captured, appended to values
+func (r *Router) lookup(path string, values []string) (cn *node, _ []string) {
+	if cn = r.statics[path]; cn != nil {
+		return cn, values
+	}
+	if r.matcher != nil {
+		// the matcher gets storage of its own, so values, which callers
+		// keep on the stack, never escapes through the interface call
+		storage := paramStoragePool.Get().(*[maxParams]string)
+		i, found := r.matcher.Match(path, storage[:0])
+		if i >= 0 {
+			cn, values = r.matcherNodes[i], append(values, found...)
+		}
+		*storage = [maxParams]string{}
+		paramStoragePool.Put(storage)
+		if cn == nil {
+			return nil, nil
+		}
+		return cn, values
+	}
+
+	// get tree base node from the router
+	cn = r.root
 
 	var (
-		search          = req.URL.Path
-		c               *node // Child node
-		n               int   // Param counter
-		collectedPnames = []string{}
+		search = path
+		c      *node // Child node
 	)
 
 	// Search order static > param > match-any
@@ -196,44 +563,10 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 
 		if search == "" {
 			if cn.resource != nil {
-				// Found route, check if method is applicable
-				theHandler, allowedMethods := cn.resource.GetMethodHandler(req.Method)
-				if theHandler == nil {
-					if uint16(req.Method[0])<<8|uint16(req.Method[1]) == 0x4f50 {
-						h = optionsHandler(r.globalCors, cn.resource.Cors, allowedMethods)
-						return
-					}
-					if allowedMethods != "" {
-						// route is valid, but method is not allowed, 405
-						h = methodNotAllowedHandler(allowedMethods)
-					}
-					return
-				}
-				h = corsFlightWrapper(r.globalCors, cn.resource.Cors, allowedMethods, theHandler)
-				for i, v := range collectedPnames {
-					if len(cn.pnames[req.Method]) > i {
-						AddParam(req, cn.pnames[req.Method][i], v)
-					}
-				}
-
-				brokenPrefix := strings.Split(prefix, "/")
-				prefix = ""
-				k := 0
-				for _, v := range brokenPrefix {
-					if v != "" {
-						prefix += "/"
-						if v == ":" {
-							if pnames, ok := cn.pnames[req.Method]; ok {
-								prefix += v + pnames[k]
-							}
-							k++
-						} else {
-							prefix += v
-						}
-					}
-				}
+				// Found resource
+				return cn, values
 			}
-			return
+			return nil, nil
 		}
 
 		pl := 0 // Prefix length
@@ -242,7 +575,6 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 		if cn.label != ':' {
 			sl := len(search)
 			pl = len(cn.prefix)
-			prefix += cn.prefix
 
 			// LCP
 			max := pl
@@ -257,7 +589,7 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 			// Continue search
 			search = search[l:]
 
-			if search == "" && cn != nil && cn.parent != nil && cn.resource.allowedMethods == "" {
+			if search == "" && cn != nil && cn.parent != nil && cn.resource.methods == 0 {
 				parent := cn.parent
 				search = cn.prefix
 				for parent != nil {
@@ -268,6 +600,8 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 					}
 					parent = parent.parent
 				}
+				// no match-any above, the path ends here
+				search = ""
 			}
 
 		}
@@ -298,13 +632,11 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 			for ; i < l && search[i] != '/'; i++ {
 			}
 
-			collectedPnames = append(collectedPnames, search[0:i])
-			prefix += ":"
-			n++
+			values = append(values, search[0:i])
 			search = search[i:]
 
 			if len(cn.children) == 0 && len(search) != 0 {
-				return
+				return nil, nil
 			}
 
 			continue
@@ -316,7 +648,7 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 		c = cn.findChildWithType(mtype)
 		if c != nil {
 			cn = c
-			collectedPnames = append(collectedPnames, search)
+			values = append(values, search)
 			search = "" // End search
 			continue
 		}
@@ -343,7 +675,7 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 		}
 
 		// Not found
-		return
+		return nil, nil
 	}
 }
 
@@ -351,7 +683,7 @@ func (r *Router) find(req *http.Request) (prefix string, h http.HandlerFunc) {
 func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames pNames, cors *CorsAccessControl) {
 	cn := r.root
 
-	if !validMethod(method) && method != "CORS" {
+	if !validMethod(method) && !isCorsMethod(method) {
 		panic("invalid method")
 	}
 	search := path
@@ -380,8 +712,8 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 			if h != nil {
 				cn.typ = t
 				cn.resource = newResource()
-				cn.resource.Cors = cn.resource.Cors.Merge(cors)
-				if method != "CORS" {
+				cn.resource.mergeCors(method, cors)
+				if !isCorsMethod(method) {
 					cn.resource.AddMethodHandler(method, h)
 				}
 				if len(cn.pnames[method]) == 0 {
@@ -397,6 +729,8 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 			cn.resource.CopyTo(nr)
 
 			n := newNode(cn.typ, cn.prefix[l:], cn, cn.children, nr, pnames)
+			n.templates = cn.templates
+			n.constraints = cn.constraints
 			for i := 0; i < len(n.children); i++ {
 				n.children[i].parent = n
 			}
@@ -408,15 +742,17 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 			cn.children = nil
 			cn.resource = newResource()
 			cn.pnames = make(pNames)
+			cn.templates = nil
+			cn.constraints = nil
 
 			cn.addChild(n)
 
 			if l == sl {
 				// At parent node
 				cn.typ = t
-				cn.resource.Cors = cn.resource.Cors.Merge(cors)
+				cn.resource.mergeCors(method, cors)
 
-				if method != "CORS" {
+				if !isCorsMethod(method) {
 					cn.resource.AddMethodHandler(method, h)
 				}
 				if len(cn.pnames[method]) == 0 {
@@ -428,8 +764,8 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 			} else {
 				// Create child node
 				nr := newResource()
-				nr.Cors = nr.Cors.Merge(cors)
-				if method != "CORS" {
+				nr.mergeCors(method, cors)
+				if !isCorsMethod(method) {
 					nr.AddMethodHandler(method, h)
 				}
 				if len(cn.pnames[method]) == 0 {
@@ -442,6 +778,10 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 				cn.addChild(n)
 				cn.pnames = make(pNames)
 			}
+			if cn.parent != nil {
+				// the type of the node may have changed
+				cn.parent.reindex()
+			}
 		} else if l < sl {
 			search = search[l:]
 			c := cn.findChildWithLabel(search[0])
@@ -452,23 +792,23 @@ func (r *Router) insert(method, path string, h http.HandlerFunc, t ntype, pnames
 			}
 			// Create child node
 			nr := newResource()
-			if method != "CORS" {
+			if !isCorsMethod(method) {
 				nr.AddMethodHandler(method, h)
 			}
-			nr.Cors = nr.Cors.Merge(cors)
+			nr.mergeCors(method, cors)
 			n := newNode(t, search, cn, nil, nr, pnames)
 			cn.addChild(n)
 			cn.resource.Clean()
 			n.resource.Clean()
 		} else {
 			if cors != nil {
-				cn.resource.Cors = cn.resource.Cors.Merge(cors)
+				cn.resource.mergeCors(method, cors)
 			}
 			// Node already exists
 			if h != nil {
 				// add the handler to the node's map of methods to handlers
 
-				if method != "CORS" {
+				if !isCorsMethod(method) {
 					cn.resource.AddMethodHandler(method, h)
 				}
 				if len(cn.pnames[method]) == 0 {
@@ -490,5 +830,5 @@ func buildChain(f http.HandlerFunc, m ...Middleware) http.HandlerFunc {
 		return f
 	}
 	// otherwise nest the handlerfuncs
-	return m[0](buildChain(f, m[1:cap(m)]...))
+	return m[0](buildChain(f, m[1:]...))
 }
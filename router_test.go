@@ -9,6 +9,7 @@
 package vestigo
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -1248,3 +1249,385 @@ func TestRouter_Issue79(t *testing.T) {
 	assert.Equal(t, w.Body.String(), "p1/p2")
 
 }
+
+func TestRouter_Use(t *testing.T) {
+	b := bytes.Buffer{}
+	var matched []bool
+	global := func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			matched = append(matched, Matched(r))
+			b.WriteString("global ")
+			f(w, r)
+		}
+	}
+	route := func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			b.WriteString("route ")
+			f(w, r)
+		}
+	}
+
+	r := NewRouter()
+	r.Use(global)
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		b.WriteString("handler " + Param(r, "id"))
+	}, route)
+
+	tests := []struct {
+		method, path string
+		code         int
+		body         string
+		matched      bool
+	}{
+		{"GET", "/users/1", http.StatusOK, "global route handler 1", true},
+		{"GET", "/groups/1", http.StatusNotFound, "global ", false},
+		{"POST", "/users/1", http.StatusMethodNotAllowed, "global ", false},
+		{"OPTIONS", "/users/1", http.StatusOK, "global ", false},
+	}
+	for _, tt := range tests {
+		b.Reset()
+		matched = nil
+		req, _ := http.NewRequest(tt.method, tt.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.code, w.Code, tt.method+" "+tt.path)
+		assert.Equal(t, tt.body, b.String(), tt.method+" "+tt.path)
+		assert.Equal(t, []bool{tt.matched}, matched, tt.method+" "+tt.path)
+	}
+}
+
+func TestRouter_Lookup(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	r.Delete("/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/static/*", func(w http.ResponseWriter, r *http.Request) {})
+
+	h, params, template, allowed := r.Lookup("GET", "/users/1/posts/2")
+	if assert.NotNil(t, h) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest("GET", "/users/1/posts/2", nil))
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	}
+	assert.Equal(t, map[string]string{"id": "1", "post": "2"}, params)
+	assert.Equal(t, "/users/:id/posts/:post", template)
+	assert.Equal(t, []string{"GET", "HEAD", "DELETE"}, allowed)
+
+	// the allowed methods are the caller's to change
+	allowed[0] = "PURGE"
+	_, _, _, allowed = r.Lookup("GET", "/users/1/posts/2")
+	assert.Equal(t, []string{"GET", "HEAD", "DELETE"}, allowed)
+	m := r.Match(httptest.NewRequest("GET", "/users/1/posts/2", nil))
+	m.AllowedMethods[0] = "PURGE"
+	assert.Equal(t, []string{"GET", "HEAD", "DELETE"}, r.Match(httptest.NewRequest("GET", "/users/1/posts/2", nil)).AllowedMethods)
+
+	h, params, template, allowed = r.Lookup("POST", "/users/1/posts/2")
+	assert.Nil(t, h)
+	assert.Equal(t, "/users/:id/posts/:post", template)
+	assert.Equal(t, []string{"GET", "HEAD", "DELETE"}, allowed)
+
+	h, params, template, _ = r.Lookup("GET", "/static/css/site.css")
+	assert.NotNil(t, h)
+	assert.Equal(t, map[string]string{"_name": "css/site.css"}, params)
+	assert.Equal(t, "/static/*", template)
+
+	h, params, template, allowed = r.Lookup("GET", "/nope")
+	assert.Nil(t, h)
+	assert.Nil(t, params)
+	assert.Equal(t, "", template)
+	assert.Nil(t, allowed)
+
+	h, _, _, _ = r.Lookup("BREW", "/users/1/posts/2")
+	assert.Nil(t, h)
+}
+
+// benchResponseWriter - a http.ResponseWriter that discards the response
+// without allocating, so the benchmarks only measure the router
+type benchResponseWriter struct {
+	header http.Header
+}
+
+func (w *benchResponseWriter) Header() http.Header         { return w.header }
+func (w *benchResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *benchResponseWriter) WriteHeader(int)             {}
+
+func benchRequest(b *testing.B, r *Router, method, path string) {
+	req, _ := http.NewRequest(method, path, nil)
+	w := &benchResponseWriter{header: http.Header{}}
+	rawQuery := req.URL.RawQuery
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req.URL.RawQuery = rawQuery
+		r.ServeHTTP(w, req)
+	}
+}
+
+func githubRouter() *Router {
+	r := NewRouter()
+	for _, route := range api {
+		r.Add(route.method, route.path, func(w http.ResponseWriter, r *http.Request) {})
+	}
+	return r
+}
+
+func BenchmarkRouter_Static(b *testing.B) {
+	benchRequest(b, githubRouter(), "GET", "/user/repos")
+}
+
+func BenchmarkRouter_Param(b *testing.B) {
+	benchRequest(b, githubRouter(), "GET", "/repos/husobee/vestigo/issues/42")
+}
+
+func BenchmarkRouter_GithubAll(b *testing.B) {
+	r := githubRouter()
+	requests := make([]*http.Request, len(api))
+	for i, route := range api {
+		requests[i], _ = http.NewRequest(route.method, route.path, nil)
+	}
+	w := &benchResponseWriter{header: http.Header{}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, req := range requests {
+			req.URL.RawQuery = ""
+			r.ServeHTTP(w, req)
+		}
+	}
+}
+
+func BenchmarkRouter_LookupStatic(b *testing.B) {
+	r := githubRouter()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Lookup("GET", "/user/repos")
+	}
+}
+
+func BenchmarkRouter_LookupParam(b *testing.B) {
+	r := githubRouter()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Lookup("GET", "/repos/husobee/vestigo/issues/42")
+	}
+}
+
+func TestRouter_ResolveAllocs(t *testing.T) {
+	r := githubRouter()
+	for _, path := range []string{"/user/repos", "/repos/husobee/vestigo/issues/42"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		rc := new(routeContext)
+		var storage [maxParams]string
+		allocs := testing.AllocsPerRun(100, func() {
+			*rc = routeContext{}
+			r.resolve(rc, req, storage[:0])
+		})
+		assert.Equal(t, 0.0, allocs, path)
+	}
+	// the copy of the allowed methods is the only allocation
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Lookup("GET", "/user/repos")
+	})
+	assert.Equal(t, 1.0, allocs)
+}
+
+func TestRouter_ServeAllocs(t *testing.T) {
+	r := githubRouter()
+	w := &benchResponseWriter{header: http.Header{}}
+	for path, want := range map[string]float64{
+		// the route context and the copy of the request carrying it
+		"/user/repos": 2,
+		// and the query string the params are added to
+		"/repos/husobee/vestigo/issues/42": 3,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		allocs := testing.AllocsPerRun(100, func() {
+			req.URL.RawQuery = ""
+			r.ServeHTTP(w, req)
+		})
+		assert.Equal(t, want, allocs, path)
+	}
+}
+
+func TestRouter_MoreParamsThanStorage(t *testing.T) {
+	r := NewRouter()
+	var got []string
+	r.Get("/:a/:b/:c/:d/:e/:f/:g/:h/:i/:j", func(w http.ResponseWriter, req *http.Request) {
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+			got = append(got, Param(req, name))
+		}
+	})
+	req, _ := http.NewRequest("GET", "/1/2/3/4/5/6/7/8/9/10", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}, got)
+}
+
+func TestRouter_ParamFromContext(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "42", Param(r, "id"))
+		assert.Equal(t, "42", r.URL.Query().Get(":id"))
+		// params added by middleware are still found in the query
+		assert.Equal(t, "v", Param(r, "extra"))
+	}, func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			AddParam(r, "extra", "v")
+			f(w, r)
+		}
+	})
+	req, _ := http.NewRequest("GET", "/users/42", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// wideRouter - a router with a wide node under /api/, one resource for every
+// letter and digit, each with a few routes of its own
+func wideRouter() (*Router, []string) {
+	const labels = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	r := NewRouter()
+	var paths []string
+	for i := 0; i < len(labels); i++ {
+		resource := "/api/" + string(labels[i]) + "resource"
+		for _, suffix := range []string{"", "/:id", "/:id/items", "/:id/items/:item", "/search"} {
+			r.Get(resource+suffix, func(w http.ResponseWriter, r *http.Request) {})
+		}
+		paths = append(paths, resource, resource+"/1/items/2", resource+"/search")
+	}
+	return r, paths
+}
+
+func BenchmarkRouter_WideLookup(b *testing.B) {
+	r, paths := wideRouter()
+	var storage [maxParams]string
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			r.lookup(path, storage[:0])
+		}
+	}
+}
+
+func TestRouter_WideNode(t *testing.T) {
+	r, paths := wideRouter()
+	for _, path := range paths {
+		_, _, template, _ := r.Lookup("GET", path)
+		if template == "" {
+			t.Errorf("no route found for %s", path)
+		}
+	}
+
+	api := r.root.findNode("/api/")
+	if assert.NotNil(t, api) {
+		// children keep the order they were added in, the index is sorted
+		assert.Equal(t, byte('0'), api.children[0].label)
+		assert.Equal(t, len(api.children), len(api.indices))
+		for i := 1; i < len(api.indices); i++ {
+			assert.True(t, api.indices[i-1] < api.indices[i])
+		}
+		for _, c := range api.children {
+			assert.Equal(t, c, api.findChildWithLabel(c.label))
+		}
+	}
+
+	// splitting a node keeps the index of its parent and its own in step
+	r = NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/userinfo", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/us", func(w http.ResponseWriter, r *http.Request) {})
+	for _, path := range []string{"/users/1", "/userinfo", "/us"} {
+		h, _, _, _ := r.Lookup("GET", path)
+		assert.NotNil(t, h, path)
+	}
+	users := r.root.findNode("/users/")
+	if assert.NotNil(t, users) {
+		assert.Equal(t, users.children[0], users.findChildWithType(ptype))
+		assert.Nil(t, users.findChildWithType(mtype))
+	}
+}
+
+func TestRouter_Fallback(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("mux " + req.URL.Path))
+	})
+	sub := NewRouter()
+	sub.Get("/", func(w http.ResponseWriter, req *http.Request) {})
+
+	r := NewRouter()
+	r.Get("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("user " + Param(req, "id")))
+	})
+	r.Mount("/sub", sub)
+	var matched []bool
+	r.Use(func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			matched = append(matched, Matched(req))
+			f(w, req)
+		}
+	})
+	r.Fallback(mux)
+
+	for _, c := range []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/users/1", 200, "user 1"},
+		{"GET", "/orders/1", 200, "mux /orders/1"},
+		{"POST", "/users", 200, "mux /users"},
+		{"POST", "/users/1", 405, "Method Not Allowed"},
+		{"GET", "/sub/nope", 404, ""},
+	} {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.path)
+		if c.body != "" {
+			assert.Equal(t, c.body, w.Body.String(), c.method+" "+c.path)
+		}
+	}
+	assert.Equal(t, uint64(2), r.FallbackHits())
+	assert.Equal(t, []bool{true, false, false, false, true}, matched, "router wide middleware wraps the fallback")
+}
+
+func TestRouter_EmptyMatchAny(t *testing.T) {
+	r := NewRouter()
+	r.Post("/files/*", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/x", func(w http.ResponseWriter, r *http.Request) {})
+
+	// the match-any node captures nothing, rather than the prefix of its parent
+	_, params, template, _ := r.Lookup("POST", "/files/")
+	assert.Equal(t, "/files/*", template)
+	assert.Equal(t, map[string]string{"_name": ""}, params)
+	h, _, _, _ := r.Lookup("POST", "/fil")
+	assert.Nil(t, h)
+}
+
+func TestRouter_ContextOutlivesDispatch(t *testing.T) {
+	r := NewRouter()
+	var ctx context.Context
+	r.Get("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		if ctx == nil {
+			ctx = req.Context()
+		}
+	})
+	req, _ := http.NewRequest("GET", "/users/42", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// another request must not recycle the context of the first
+	req, _ = http.NewRequest("GET", "/users/7", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	m, _ := ctx.Value(matchKey).(*RouteMatch)
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "/users/:id", m.Template)
+		assert.Equal(t, map[string]string{"id": "42"}, m.Params)
+	}
+	assert.NotNil(t, ctx.Value(routeKey))
+	assert.Nil(t, ctx.Err())
+}
@@ -6,6 +6,8 @@
 package vestigo
 
 import (
+	"net/http"
+	"regexp"
 	"strings"
 )
 
@@ -18,6 +20,22 @@ type node struct {
 	children children
 	resource *resource
 	pnames   pNames
+	// templates - map of method to the path template the method was added
+	// with, kept here so lookups do not have to rebuild it
+	templates map[string]string
+	// constraints - map of method to the regular expressions the url params
+	// of the route have to match, in the order of the pnames of the method
+	constraints map[string][]*regexp.Regexp
+	// indices - the labels of the children, sorted, with indexed holding the
+	// first child added with each label in the same order
+	indices []byte
+	indexed []*node
+	// paramChild, anyChild - the first param and match-any children added
+	paramChild *node
+	anyChild   *node
+	// id - the position of the node in the compiled tree, which indexes the
+	// routes worked out by Compile
+	id int
 }
 
 // pNames - map of method to pnames, as different methods can have different pnames
@@ -35,36 +53,88 @@ func newNode(t ntype, pre string, p *node, c children, h *resource, pnames pName
 		resource: h,
 		pnames:   pnames,
 	}
+	n.reindex()
 	return n
 }
 
 // addChild - Add a child node to this node
 func (n *node) addChild(c *node) {
 	n.children = append(n.children, c)
+	n.reindex()
 }
 
-// findChild - find a child node of this node
-func (n *node) findChild(search string, t ntype) *node {
+// reindex - rebuild the child indexes of this node, which has to be done
+// whenever the children, or the labels or types of the children, change
+func (n *node) reindex() {
+	n.indices, n.indexed = n.indices[:0], n.indexed[:0]
+	n.paramChild, n.anyChild = nil, nil
 	for _, c := range n.children {
-		if strings.HasPrefix(search, c.prefix) && c.typ == t {
-			return c
+		switch c.typ {
+		case ptype:
+			if n.paramChild == nil {
+				n.paramChild = c
+			}
+		case mtype:
+			if n.anyChild == nil {
+				n.anyChild = c
+			}
 		}
+		i := n.labelIndex(c.label)
+		if i < len(n.indices) && n.indices[i] == c.label {
+			// keep the child added first
+			continue
+		}
+		n.indices = append(n.indices, 0)
+		copy(n.indices[i+1:], n.indices[i:])
+		n.indices[i] = c.label
+		n.indexed = append(n.indexed, nil)
+		copy(n.indexed[i+1:], n.indexed[i:])
+		n.indexed[i] = c
+	}
+}
+
+// labelIndex - the position of the label in the sorted child labels, or the
+// position it would be inserted at
+func (n *node) labelIndex(l byte) int {
+	lo, hi := 0, len(n.indices)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if n.indices[m] < l {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
+
+// findChild - find a child node of this node
+func (n *node) findChild(search string, t ntype) *node {
+	if search == "" {
+		return nil
+	}
+	if c := n.findChildWithLabel(search[0]); c != nil && c.typ == t && strings.HasPrefix(search, c.prefix) {
+		return c
 	}
 	return nil
 }
 
 // findChildWithLabel - find a child with a matching label, label being the first byte in the prefix
 func (n *node) findChildWithLabel(l byte) *node {
-	for _, c := range n.children {
-		if c.label == l {
-			return c
-		}
+	if i := n.labelIndex(l); i < len(n.indices) && n.indices[i] == l {
+		return n.indexed[i]
 	}
 	return nil
 }
 
 // findChildWithType - find a child with a matching type
 func (n *node) findChildWithType(t ntype) *node {
+	switch t {
+	case ptype:
+		return n.paramChild
+	case mtype:
+		return n.anyChild
+	}
 	for _, c := range n.children {
 		if c.typ == t {
 			return c
@@ -72,3 +142,64 @@ func (n *node) findChildWithType(t ntype) *node {
 	}
 	return nil
 }
+
+// describeMethods - the order in which the methods of a node are tried when
+// describing it for a method that was not added to it
+var describeMethods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodTrace,
+}
+
+// pnamesFor - get the param names used by a method on this node, falling back
+// to the names used by any other method when the method has none
+func (n *node) pnamesFor(method string) []string {
+	if pnames, ok := n.pnames[method]; ok {
+		return pnames
+	}
+	for _, m := range describeMethods {
+		if pnames, ok := n.pnames[m]; ok {
+			return pnames
+		}
+	}
+	return nil
+}
+
+// templateFor - get the path template a method was added to this node with,
+// falling back to the template of any other method when the method has none
+func (n *node) templateFor(method string) string {
+	if template, ok := n.templates[method]; ok {
+		return template
+	}
+	for _, m := range describeMethods {
+		if template, ok := n.templates[m]; ok {
+			return template
+		}
+	}
+	return ""
+}
+
+// setTemplate - record the path template a method was added to this node with
+func (n *node) setTemplate(method, template string) {
+	if n.templates == nil {
+		n.templates = make(map[string]string)
+	}
+	n.templates[method] = template
+	if method == http.MethodGet {
+		n.templates[http.MethodHead] = template
+	}
+}
+
+// findNode - find the node a path, with its param names stripped as they are
+// when inserted, was inserted at
+func (n *node) findNode(path string) *node {
+	for cn := n; cn != nil; cn = cn.findChildWithLabel(path[0]) {
+		if !strings.HasPrefix(path, cn.prefix) {
+			return nil
+		}
+		path = path[len(cn.prefix):]
+		if path == "" {
+			return cn
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionOptions - How the router tells the API version requests ask for, see
+// Router.Versioning.  The strategies configured are tried in the order path,
+// header and media type, the first finding a version winning.
+type VersionOptions struct {
+	// PathPrefix - versions are path prefixes, such as "/v" for /v2/users,
+	// which is routed as /users
+	PathPrefix string
+	// Header - versions are the value of a header, such as API-Version
+	Header string
+	// Vendor - versions are part of the vendor media types of the Accept
+	// header, such as application/vnd.<vendor>.v2+json, or given by a version
+	// parameter, such as application/json; version=2
+	Vendor string
+	// Default - the version of requests asking for none
+	Default string
+	// Deprecated - the deprecated versions, responses to requests asking for
+	// them carrying Deprecation and Sunset headers
+	Deprecated map[string]Deprecation
+}
+
+// Deprecation - When a version was deprecated, and when it is going away
+type Deprecation struct {
+	// Date - when the version was deprecated, the Deprecation header is
+	// "true" when zero
+	Date time.Time
+	// Sunset - when the version stops being served, no Sunset header is sent
+	// when zero
+	Sunset time.Time
+	// Link - documentation of the deprecation, sent as a Link header
+	Link string
+}
+
+// Versioning - Tell the API version of requests by the options given, which
+// Route declarations with a Version are then chosen among by.  A route serves
+// the newest version it has that is not newer than the version asked for, so a
+// route unchanged since version 2 also serves version 3, and routes declared
+// without a version serve every version.  APIVersion gets the version of a
+// request.
+//
+//	router.Versioning(&vestigo.VersionOptions{PathPrefix: "/v", Header: "API-Version", Default: "2"})
+//	router.Route("GET", "/users/:id").Version("1").Handle(UserV1)
+//	router.Route("GET", "/users/:id").Version("2").Handle(UserV2)
+func (r *Router) Versioning(opts *VersionOptions) {
+	r.mustNotBeCompiled()
+	installed := r.versioning != nil
+	o := *opts
+	r.versioning = &o
+	if !installed {
+		r.Pre(r.versionRequest)
+	}
+}
+
+// Versions - The versions of the routes declared with a Version, by method and
+// path, such as "GET /users/:id"
+func (r *Router) Versions() map[string][]string {
+	versions := make(map[string][]string)
+	for key, n := range r.negotiators {
+		for _, v := range n.variants {
+			if v.version != "" && !containsString(versions[key.method+" "+key.path], v.version) {
+				versions[key.method+" "+key.path] = append(versions[key.method+" "+key.path], v.version)
+			}
+		}
+	}
+	for _, v := range versions {
+		sort.Slice(v, func(i, j int) bool { return compareVersions(v[i], v[j]) < 0 })
+	}
+	return versions
+}
+
+// APIVersion - Get the API version a request asks for, or the default version
+// when it asks for none, see Router.Versioning
+func APIVersion(r *http.Request) string {
+	version, _ := r.Context().Value(versionKey).(string)
+	return version
+}
+
+// versionRequest - pre-routing middleware telling the version of requests
+func (r *Router) versionRequest(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		o := r.versioning
+		ctx := req.Context()
+		version, segments := o.pathVersion(req.URL.Path)
+		if version != "" {
+			if _, ok := ctx.Value(mountKey).(string); !ok {
+				ctx = context.WithValue(ctx, mountKey, req.URL.Path)
+			}
+			u := *req.URL
+			u.Path = stripSegments(u.Path, segments)
+			if u.RawPath != "" {
+				u.RawPath = stripSegments(u.RawPath, segments)
+			}
+			req = req.WithContext(ctx)
+			req.URL = &u
+		}
+		if o.Header != "" {
+			addVary(w.Header(), o.Header)
+			if version == "" {
+				version = strings.TrimSpace(req.Header.Get(o.Header))
+			}
+		}
+		if o.Vendor != "" {
+			addVary(w.Header(), "Accept")
+			if version == "" {
+				version = o.mediaTypeVersion(req.Header.Get("Accept"))
+			}
+		}
+		if version == "" {
+			version = o.Default
+		}
+
+		if d, ok := o.Deprecated[version]; ok {
+			d.setHeaders(w.Header())
+		}
+		next(w, req.WithContext(context.WithValue(req.Context(), versionKey, version)))
+	}
+}
+
+// pathVersion - the version a path is prefixed with, and the number of
+// segments the prefix takes up
+func (o *VersionOptions) pathVersion(path string) (string, int) {
+	if o.PathPrefix == "" || !strings.HasPrefix(path, o.PathPrefix) {
+		return "", 0
+	}
+	version := path[len(o.PathPrefix):]
+	if i := strings.IndexByte(version, '/'); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return "", 0
+	}
+	return version, strings.Count(o.PathPrefix, "/")
+}
+
+// mediaTypeVersion - the version asked for by an Accept header, by a vendor
+// media type or a version parameter
+func (o *VersionOptions) mediaTypeVersion(accept string) string {
+	vendor := "vnd." + strings.ToLower(o.Vendor) + "."
+	for _, v := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		if version := params["version"]; version != "" {
+			return version
+		}
+		subtype := mediaType[strings.IndexByte(mediaType, '/')+1:]
+		if i := strings.IndexByte(subtype, '+'); i >= 0 {
+			subtype = subtype[:i]
+		}
+		if strings.HasPrefix(subtype, vendor+"v") {
+			return subtype[len(vendor)+1:]
+		}
+	}
+	return ""
+}
+
+// setHeaders - set the Deprecation, Sunset and Link headers of a deprecated
+// version
+func (d Deprecation) setHeaders(h http.Header) {
+	if d.Date.IsZero() {
+		h.Set("Deprecation", "true")
+	} else {
+		h.Set("Deprecation", "@"+strconv.FormatInt(d.Date.Unix(), 10))
+	}
+	if !d.Sunset.IsZero() {
+		h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Link != "" {
+		h.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", d.Link))
+	}
+}
+
+// compareVersions - compare versions by their dot separated parts, numerically
+// where both parts are numbers
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// containsString - whether the string is in the list
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2015 Husobee Associates, LLC.  All rights reserved.
+// Use of this source code is governed by The MIT License, which
+// can be found in the LICENSE file included.
+
+package vestigo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterVersioning(t *testing.T) {
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(body + " " + APIVersion(req) + " " + req.URL.Path + " " + OriginalPath(req)))
+		}
+	}
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRouter()
+	r.Versioning(&VersionOptions{
+		PathPrefix: "/v",
+		Header:     "API-Version",
+		Vendor:     "acme",
+		Default:    "2",
+		Deprecated: map[string]Deprecation{
+			"1": {Date: time.Unix(1700000000, 0), Sunset: sunset, Link: "https://example.com/v1"},
+		},
+	})
+	r.Route("GET", "/users/:id").Version("1").Handle(respond("users1"))
+	r.Route("GET", "/users/:id").Version("2").Produces("application/json").Handle(respond("users2"))
+	r.Route("GET", "/users/:id").Version("3").Handle(respond("users3"))
+	r.Route("GET", "/orders").Version("2").Handle(respond("orders2"))
+	r.Get("/status", respond("status"))
+
+	for _, c := range []struct {
+		path, header, accept string
+		code                 int
+		want                 string
+	}{
+		{"/users/1", "", "", 200, "users2 2 /users/1 /users/1"},
+		{"/v1/users/1", "", "", 200, "users1 1 /users/1 /v1/users/1"},
+		{"/v3/users/1", "", "", 200, "users3 3 /users/1 /v3/users/1"},
+		{"/users/1", "1", "", 200, "users1 1 /users/1 /users/1"},
+		{"/users/1", "", "application/vnd.acme.v3+json", 200, "users3 3 /users/1 /users/1"},
+		{"/users/1", "", "application/vnd.acme.v2+json", 200, "users2 2 /users/1 /users/1"},
+		{"/users/1", "", "application/json; version=1", 200, "users1 1 /users/1 /users/1"},
+		// the path wins over the header
+		{"/v3/users/1", "1", "", 200, "users3 3 /users/1 /v3/users/1"},
+		// a route unchanged since version 2 serves version 3
+		{"/v3/orders", "", "", 200, "orders2 3 /orders /v3/orders"},
+		{"/v1/orders", "", "", 404, ""},
+		// routes without a version serve every version
+		{"/v7/status", "", "", 200, "status 7 /status /v7/status"},
+		{"/v2/users/1", "", "text/html", 406, ""},
+	} {
+		req, _ := http.NewRequest("GET", c.path, nil)
+		if c.header != "" {
+			req.Header.Set("API-Version", c.header)
+		}
+		if c.accept != "" {
+			req.Header.Set("Accept", c.accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code, "%s %q %q", c.path, c.header, c.accept)
+		if c.code == 200 {
+			assert.Equal(t, c.want, w.Body.String(), "%s %q %q", c.path, c.header, c.accept)
+		}
+		assert.Equal(t, []string{"API-Version", "Accept"}, w.Header()["Vary"], c.path)
+
+		deprecated := strings.HasPrefix(c.path, "/v1/") || c.header == "1" && !strings.HasPrefix(c.path, "/v3/") ||
+			strings.HasSuffix(c.accept, "version=1")
+		if deprecated {
+			assert.Equal(t, "@1700000000", w.Header().Get("Deprecation"))
+			assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+			assert.Equal(t, `<https://example.com/v1>; rel="deprecation"`, w.Header().Get("Link"))
+		} else {
+			assert.Empty(t, w.Header().Get("Deprecation"), c.path)
+		}
+	}
+
+	assert.Equal(t, map[string][]string{
+		"GET /users/:id": {"1", "2", "3"},
+		"GET /orders":    {"2"},
+	}, r.Versions())
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.True(t, compareVersions("2", "10") < 0)
+	assert.True(t, compareVersions("1.10", "1.9") > 0)
+	assert.True(t, compareVersions("1.2", "1.2.1") < 0)
+	assert.Equal(t, 0, compareVersions("2024-01-01", "2024-01-01"))
+	assert.True(t, compareVersions("2023-12-01", "2024-01-01") < 0)
+}